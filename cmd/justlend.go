@@ -1,25 +1,54 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/oklog/run"
 	"justlend/internal/config"
+	"justlend/internal/database"
 	"justlend/internal/justlend"
+	"justlend/internal/justlend/grpc"
 	"justlend/internal/justlend/http"
 	"justlend/internal/log"
+	"justlend/internal/remotesigner"
 	"justlend/internal/repos"
 	"justlend/internal/tron"
+	"justlend/internal/wallet"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func main() {
+	// Dispatch the subcommands before starting the daemon.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "keystore":
+			runKeystore(os.Args[2:])
+			return
+		case "signer":
+			runSigner(os.Args[2:])
+			return
+		case "sign":
+			runSign(os.Args[2:])
+			return
+		}
+	}
+
 	// Construct a new type to represent our application daemon.
 	// This type lets us shared setup code with our end-to-end tests.
 	d := newDaemon()
 
 	d.StartHTTPServer()
+	d.StartGRPCServer()
+	d.StartHealthChecks()
+	d.StartReconciler()
+	d.StartReturnScheduler()
+	d.StartWatchdog()
+	d.StartMaintainer()
 	// This function just sits and waits for ctrl-C.
 	w := make(chan struct{})
 	d.Add(func() error {
@@ -48,24 +77,87 @@ type daemon struct {
 	run.Group                   // Embed `run.Group` for running actors.
 	Config     *config.Config   // Resolved config data.
 	HTTPServer *http.Server     // HTTP server for handling HTTP communication.trxEnergy service is attached to it before running.
+	GRPCServer *grpc.Server     // gRPC server of the justlend.v1 API, nil if it's disabled.
 	Service    justlend.Service // application service.
 	Endpoint   *tron.Endpoint
+	Signer     tron.Signer  // Signer of the wallets, nil if no signer configured.
+	DB         *database.DB // Database of the ledger.
 }
 
+// newEndpoint connects the Tron nodes of the config, the end-to-end tests
+// connect a fake node instead.
+var newEndpoint = tron.NewEndpoint
+
 func newDaemon() *daemon {
 	d := &daemon{}
 	var err error
 	d.Config = config.Resolve()
+	// The keys are off by default for the existing deployments, & with the
+	// keys on but no admin key no key could be issued, so every request
+	// would be refused.
+	if !d.Config.APIKeyEnabled {
+		log.Warn("API_KEY_ENABLED not set, the HTTP & gRPC APIs accept unauthenticated requests")
+	} else if d.Config.AdminAPIKey == "" {
+		log.FatalW("cannot authenticate API keys", "error", "ADMIN_API_KEY is required with API_KEY_ENABLED=true")
+	}
 
-	if d.Endpoint, err = tron.NewEndpoint(); err != nil {
+	if d.Endpoint, err = newEndpoint(); err != nil {
 		log.FatalW("cannot connect tron", "error", err)
 	}
 
-	d.Service = repos.NewService(d.Endpoint)
+	if d.Signer, err = newSigner(d.Config); err != nil {
+		log.FatalW("cannot construct signer", "signer", d.Config.Signer, "error", err)
+	}
+
+	if d.DB, err = database.Open(d.Config.DBDriver, d.Config.DBSource); err != nil {
+		log.FatalW("cannot open database", "driver", d.Config.DBDriver, "error", err)
+	}
+	if err = repos.Migrate(context.Background(), d.DB); err != nil {
+		log.FatalW("cannot migrate database", "error", err)
+	}
+
+	d.Service = repos.NewService(d.Endpoint, d.Signer, d.DB)
 
 	return d
 }
 
+// newSigner constructs the signer of the wallets selected by the config.
+func newSigner(c *config.Config) (tron.Signer, error) {
+	switch c.Signer {
+	case "local":
+		return tron.NewLocalSigner(c.SignerKeys...)
+	case "remote":
+		if c.SignerURL == "" {
+			return nil, fmt.Errorf("SIGNER_URL required")
+		}
+		// The grpc:// & grpcs:// URLs call the gRPC API of the signer
+		// server, the other ones its HTTP API.
+		u, err := url.Parse(c.SignerURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SIGNER_URL: %w", err)
+		}
+		switch u.Scheme {
+		case "grpc", "grpcs":
+			return remotesigner.NewGRPCClient(u.Host, c.SignerToken, u.Scheme == "grpcs")
+		}
+		return remotesigner.NewClient(c.SignerURL, c.SignerToken), nil
+	case "keystore":
+		// The keystore is optional, requests referring to a
+		// wallet are rejected if it's not configured.
+		if c.KeystoreDir == "" {
+			return nil, nil
+		}
+		ks, err := wallet.Open(c.KeystoreDir, c.KeystorePassword)
+		if err != nil {
+			return nil, err
+		}
+		log.InfoW("keystore unlocked", "dir", c.KeystoreDir, "wallets", len(ks.Wallets()))
+		return ks, nil
+	default:
+		return nil, fmt.Errorf("unknown signer %q", c.Signer)
+	}
+}
+
 func (d *daemon) StartHTTPServer() {
 	// Construct HTTP server.
 	d.HTTPServer = http.NewServer(d.Service, d.Config)
@@ -89,16 +181,97 @@ func (d *daemon) StartHTTPServer() {
 	}
 }
 
+// StartGRPCServer serves the gRPC API on its own port unless it's disabled.
+func (d *daemon) StartGRPCServer() {
+	if d.Config.GRPCAddr == "" {
+		return
+	}
+	d.GRPCServer = grpc.NewServer(d.Service, d.Config)
+	d.Add(func() error {
+		log.InfoW("Running justlend gRPC server", "transport", "gRPC", "addr", d.Config.GRPCAddr)
+		return d.GRPCServer.Open()
+	}, func(error) {
+		d.GRPCServer.Close()
+	})
+}
+
+// StartHealthChecks probes the health of the Tron nodes in background.
+func (d *daemon) StartHealthChecks() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Add(func() error {
+		log.InfoW("Running tron node health checks", "nodes", len(d.Endpoint.Nodes()))
+		if err := d.Endpoint.Run(ctx); !errors.Is(err, context.Canceled) {
+			return err
+		}
+		return nil
+	}, func(error) {
+		cancel()
+	})
+}
+
+// StartReconciler settles the broadcast orders of the ledger in background.
+func (d *daemon) StartReconciler() {
+	d.every("ledger reconciler", d.Config.ReconcileInterval, d.Service.Reconcile)
+}
+
+// StartReturnScheduler returns the rentals whose durations elapse in background.
+func (d *daemon) StartReturnScheduler() {
+	d.every("return scheduler", d.Config.ReturnInterval, d.Service.ReturnDue)
+}
+
+// StartWatchdog watches the deposits of the rentals in background.
+func (d *daemon) StartWatchdog() {
+	d.every("liquidation watchdog", d.Config.WatchInterval, d.Service.Watch)
+}
+
+// StartMaintainer keeps the energy of the receivers at the targets in background.
+func (d *daemon) StartMaintainer() {
+	d.every("energy maintainer", d.Config.MaintainInterval, d.Service.Maintain)
+}
+
+// every runs the job every interval as an actor until the daemon stops, the
+// failures are logged & the job is run again on the next tick.
+func (d *daemon) every(name string, interval time.Duration, job func(context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Add(func() error {
+		log.InfoW("Running "+name, "interval", interval)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-t.C:
+			}
+			if err := job(ctx); err != nil && ctx.Err() == nil {
+				log.ErrorW(name+" failed", "error", err)
+			}
+		}
+	}, func(error) {
+		cancel()
+	})
+}
+
 func (d *daemon) Close() error {
 	if d.HTTPServer != nil {
 		if err := d.HTTPServer.Close(); err != nil {
 			return err
 		}
 	}
+	if d.GRPCServer != nil {
+		if err := d.GRPCServer.Close(); err != nil {
+			return err
+		}
+	}
 	if d.Endpoint != nil {
 		if err := d.Endpoint.Close(); err != nil {
 			return err
 		}
 	}
+	if d.DB != nil {
+		if err := d.DB.Close(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,689 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"justlend/internal/justlend"
+	justlendv1 "justlend/internal/protos/justlend/v1"
+	"justlend/internal/remotesigner"
+	"justlend/internal/tron"
+	"justlend/internal/tron/tronfake"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	// ownerKey is the key of the renter, which is held by the local signer.
+	ownerKey    = "8e812436a0e3323166e1f0e8ba79e19e217b2c4a53c970d4cca0cfb1078979df"
+	receiverKey = "2f4e1b0e9f2a4c6b8d0f1e3a5c7b9d1f3e5a7c9b1d3f5e7a9c1b3d5f7e9a1c3b"
+	// adminKey is the root API key of the daemon, which the requests carry
+	// unless a test switches the key.
+	adminKey = "test-admin-key"
+	// signerToken authenticates the daemon to the remote signer.
+	signerToken = "test-signer-token"
+	// rentAmount is the energy rented by the tests, which stakes 7223 TRX
+	// with the default totals of the fake node.
+	rentAmount = 65000
+)
+
+// testDaemon is the daemon of the end-to-end tests, which is connected to a
+// fake node & serves HTTP & gRPC on the local ports.
+type testDaemon struct {
+	*daemon
+	node            *tronfake.Server
+	url, grpcAddr   string
+	owner, receiver string
+	// apiKey is the API key of the requests.
+	apiKey string
+}
+
+func newTestDaemon(t *testing.T) *testDaemon {
+	t.Helper()
+	return startTestDaemon(t, nil)
+}
+
+// startTestDaemon is like newTestDaemon, but the env overrides the settings
+// of the daemon.
+func startTestDaemon(t *testing.T, env map[string]string) *testDaemon {
+	t.Helper()
+	node := tronfake.New(tronfake.DefaultParams())
+	t.Cleanup(node.Close)
+	newEndpoint = node.Endpoint
+	t.Cleanup(func() { newEndpoint = tron.NewEndpoint })
+
+	addr, grpcAddr := freeAddr(t), freeAddr(t)
+	t.Setenv("MS_ADDR", addr)
+	t.Setenv("GRPC_ADDR", grpcAddr)
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("DB_SOURCE", filepath.Join(t.TempDir(), "justlend.db"))
+	t.Setenv("SIGNER", "local")
+	t.Setenv("SIGNER_PRIVATE_KEYS", ownerKey)
+	t.Setenv("API_KEY_ENABLED", "true")
+	t.Setenv("ADMIN_API_KEY", adminKey)
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	d := &testDaemon{daemon: newDaemon(), node: node, url: "http://" + addr, grpcAddr: grpcAddr}
+	if d.Config.APIKeyEnabled {
+		d.apiKey = d.Config.AdminAPIKey
+	}
+	d.owner, d.receiver = addressOf(t, ownerKey), addressOf(t, receiverKey)
+	node.SetBalance(d.owner, 1000*tron.SUNPerTRX)
+
+	d.StartHTTPServer()
+	d.StartGRPCServer()
+	done := make(chan error, 1)
+	go func() { done <- d.Run() }()
+	t.Cleanup(func() {
+		d.Close()
+		<-done
+	})
+	// Wait until the servers accept the connections.
+	for _, addr := range []string{addr, grpcAddr} {
+		for i := 0; ; i++ {
+			c, err := net.Dial("tcp", addr)
+			if err == nil {
+				c.Close()
+				break
+			} else if i == 100 {
+				t.Fatalf("server not started: %v", err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	return d
+}
+
+// newRemoteTestDaemon is like newTestDaemon, but the daemon signs through a
+// remote signer server holding the key of the renter, over HTTP or gRPC.
+func newRemoteTestDaemon(t *testing.T, transport string) *testDaemon {
+	t.Helper()
+	signer, err := tron.NewLocalSigner(ownerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signerURL string
+	switch transport {
+	case "grpc":
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv := remotesigner.NewGRPCServer(signer, signerToken)
+		go srv.Serve(ln)
+		t.Cleanup(srv.Stop)
+		signerURL = "grpc://" + ln.Addr().String()
+	default:
+		srv := httptest.NewServer(remotesigner.NewHandler(signer, signerToken))
+		t.Cleanup(srv.Close)
+		signerURL = srv.URL
+	}
+	return startTestDaemon(t, map[string]string{
+		"SIGNER":              "remote",
+		"SIGNER_URL":          signerURL,
+		"SIGNER_TOKEN":        signerToken,
+		"SIGNER_PRIVATE_KEYS": "",
+	})
+}
+
+// freeAddr reserves a free local port for a server.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func addressOf(t *testing.T, key string) string {
+	t.Helper()
+	s, err := tron.NewLocalSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.Addresses()[0]
+}
+
+// do sends the request with the JSON body if any, decodes the data of the
+// response into data & returns the code of the response.
+func (d *testDaemon) do(t *testing.T, method, path string, body, data interface{}) int {
+	t.Helper()
+	var r bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&r).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, d.url+path, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.apiKey != "" {
+		req.Header.Set("X-APIKEY", d.apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var res struct {
+		Code  int             `json:"code"`
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	if data != nil && len(res.Data) > 0 {
+		if err = json.Unmarshal(res.Data, data); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+	return res.Code
+}
+
+// as returns the daemon sending the requests with the API key, no key is
+// sent if it's empty.
+func (d *testDaemon) as(key string) *testDaemon {
+	c := *d
+	c.apiKey = key
+	return &c
+}
+
+// mustDo is like do but fails the test unless the request succeeds.
+func (d *testDaemon) mustDo(t *testing.T, method, path string, body, data interface{}) {
+	t.Helper()
+	if code := d.do(t, method, path, body, data); code != 1000 {
+		t.Fatalf("%s %s: code %d", method, path, code)
+	}
+}
+
+func (d *testDaemon) order(t *testing.T, id string) *justlend.OrderRL {
+	t.Helper()
+	o := new(justlend.OrderRL)
+	d.mustDo(t, http.MethodGet, "/orders/"+id, nil, o)
+	return o
+}
+
+func (d *testDaemon) rentals(t *testing.T) []*justlend.RentalRL {
+	t.Helper()
+	var rls []*justlend.RentalRL
+	d.mustDo(t, http.MethodGet, fmt.Sprintf("/rentals?renter=%s&receiver=%s&type=1", d.owner, d.receiver), nil, &rls)
+	return rls
+}
+
+func TestFee(t *testing.T) {
+	d := newTestDaemon(t)
+
+	var fee justlend.FeeRatioRL
+	d.mustDo(t, http.MethodGet, fmt.Sprintf("/fee?energy=%d&type=1", rentAmount), nil, &fee)
+	if fee.StakePerTrx != 7223 {
+		t.Errorf("stakePerTrx = %d, want 7223", fee.StakePerTrx)
+	}
+	if !fee.PrePayFee.IsPositive() || fee.OrderId == "" {
+		t.Errorf("prePayFee = %v, orderId = %q", fee.PrePayFee, fee.OrderId)
+	}
+
+	// The quote fails rather than quoting a zero fee.
+	d.node.Fail("feeRatio")
+	if code := d.do(t, http.MethodGet, fmt.Sprintf("/fee?energy=%d&type=1", rentAmount), nil, nil); code != 4008 {
+		t.Errorf("fee of unavailable node: code %d, want 4008", code)
+	}
+}
+
+func TestRentAndReturn(t *testing.T) {
+	d := newTestDaemon(t)
+
+	var rent justlend.RentResourceRL
+	d.mustDo(t, http.MethodPost, "/rent?wait=true", map[string]interface{}{
+		"receive": d.receiver,
+		"type":    1,
+		"amount":  rentAmount,
+		"wallet":  d.owner,
+	}, &rent)
+	if rent.Receipt == nil || rent.Receipt.Status != string(tron.TxConfirmed) {
+		t.Fatalf("rent receipt = %+v", rent.Receipt)
+	}
+	if limit, _ := d.node.Energy(d.receiver); limit < rentAmount {
+		t.Errorf("receiver energy = %d, want at least %d", limit, rentAmount)
+	}
+	if rls := d.rentals(t); len(rls) != 1 || rls[0].StakePerTrx != rent.StakePerTrx {
+		t.Fatalf("rentals = %+v, want a stake of %d", rls, rent.StakePerTrx)
+	}
+	if o := d.order(t, rent.OrderId); o.Status != justlend.OrderConfirmed || o.TxId != rent.TxId {
+		t.Errorf("rent order = %s %s, want %s %s", o.Status, o.TxId, justlend.OrderConfirmed, rent.TxId)
+	}
+
+	var ret justlend.ReturnResourceRL
+	d.mustDo(t, http.MethodPost, "/return?wait=true", map[string]interface{}{
+		"receive": d.receiver,
+		"type":    1,
+		"wallet":  d.owner,
+	}, &ret)
+	if ret.Receipt == nil || ret.Receipt.Status != string(tron.TxConfirmed) {
+		t.Fatalf("return receipt = %+v", ret.Receipt)
+	}
+	if rls := d.rentals(t); len(rls) != 0 {
+		t.Errorf("rentals after return = %+v", rls)
+	}
+	if o := d.order(t, rent.OrderId); o.Status != justlend.OrderReturned {
+		t.Errorf("rent order = %s, want %s", o.Status, justlend.OrderReturned)
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	for _, transport := range []string{"http", "grpc"} {
+		t.Run(transport, func(t *testing.T) {
+			d := newRemoteTestDaemon(t, transport)
+
+			var rent justlend.RentResourceRL
+			d.mustDo(t, http.MethodPost, "/rent?wait=true", map[string]interface{}{
+				"receive": d.receiver,
+				"type":    1,
+				"amount":  rentAmount,
+				"wallet":  d.owner,
+			}, &rent)
+			if rent.Receipt == nil || rent.Receipt.Status != string(tron.TxConfirmed) {
+				t.Fatalf("rent receipt = %+v", rent.Receipt)
+			}
+			// The remote signer holds no key of the receiver.
+			if code := d.do(t, http.MethodPost, "/rent", map[string]interface{}{
+				"receive": d.owner,
+				"type":    1,
+				"amount":  rentAmount,
+				"wallet":  d.receiver,
+			}, nil); code != 4001 {
+				t.Errorf("rent of an unknown wallet: code %d, want 4001", code)
+			}
+		})
+	}
+}
+
+func TestReturnReverted(t *testing.T) {
+	d := newTestDaemon(t)
+
+	// Nothing is rented, so the return would revert & is refused.
+	code := d.do(t, http.MethodPost, "/return?wait=true", map[string]interface{}{
+		"receive":     d.receiver,
+		"type":        1,
+		"stakePerTrx": 1000 * tron.SUNPerTRX,
+		"wallet":      d.owner,
+	}, nil)
+	if code != 4002 {
+		t.Fatalf("return: code %d, want 4002", code)
+	}
+	var failed struct {
+		Lines []*justlend.OrderRL `json:"lines"`
+	}
+	d.mustDo(t, http.MethodGet, "/orders?status=failed", nil, &failed)
+	if len(failed.Lines) != 1 || failed.Lines[0].Kind != justlend.OrderReturn {
+		t.Errorf("failed orders = %+v, want the return", failed.Lines)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	// The daemon holds no key, the dry runs need none.
+	d := startTestDaemon(t, map[string]string{"SIGNER_PRIVATE_KEYS": ""})
+
+	var rent justlend.RentResourceRL
+	d.mustDo(t, http.MethodPost, "/rent?dryRun=true", map[string]interface{}{
+		"owner":   d.owner,
+		"receive": d.receiver,
+		"type":    1,
+		"amount":  rentAmount,
+	}, &rent)
+	if rent.DryRun == nil || rent.DryRun.Owner != d.owner || rent.DryRun.ContractResult != "SUCCESS" {
+		t.Fatalf("dry run = %+v", rent.DryRun)
+	}
+	if rent.OrderId != "" {
+		t.Errorf("dry run recorded order %s", rent.OrderId)
+	}
+	if code := d.do(t, http.MethodPost, "/rent?dryRun=true", map[string]interface{}{
+		"receive": d.receiver,
+		"type":    1,
+		"amount":  rentAmount,
+		"wallet":  d.owner,
+	}, nil); code != 4002 {
+		t.Errorf("dry run of a wallet: code %d, want 4002", code)
+	}
+}
+
+func TestOfflineSigning(t *testing.T) {
+	d := newTestDaemon(t)
+
+	var rent justlend.RentResourceRL
+	d.mustDo(t, http.MethodPost, "/rent/unsigned", map[string]interface{}{
+		"owner":   d.owner,
+		"receive": d.receiver,
+		"type":    1,
+		"amount":  rentAmount,
+	}, &rent)
+	if rent.Unsigned == nil || rent.TxId != rent.Unsigned.TxId {
+		t.Fatalf("unsigned rent = %+v", rent)
+	}
+	if o := d.order(t, rent.OrderId); o.Status != justlend.OrderCreated || o.TxId != rent.TxId {
+		t.Errorf("order = %s %s, want %s %s", o.Status, o.TxId, justlend.OrderCreated, rent.TxId)
+	}
+
+	// Sign the exported raw data as the offline machine does.
+	tx, err := tron.DecodeRawData(rent.Unsigned.RawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, _ := tron.NewLocalSigner(ownerKey)
+	if _, err = tron.SignTransaction(context.Background(), tx, signer, d.owner); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := tron.EncodeTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rl justlend.BroadcastRL
+	d.mustDo(t, http.MethodPost, "/broadcast?wait=true", map[string]interface{}{"transaction": signed}, &rl)
+	if rl.TxId != rent.TxId || rl.OrderId != rent.OrderId {
+		t.Errorf("broadcast = %s %s, want %s %s", rl.TxId, rl.OrderId, rent.TxId, rent.OrderId)
+	}
+	if rl.Receipt == nil || rl.Receipt.Status != string(tron.TxConfirmed) {
+		t.Fatalf("broadcast receipt = %+v", rl.Receipt)
+	}
+	if o := d.order(t, rent.OrderId); o.Status != justlend.OrderConfirmed {
+		t.Errorf("order = %s, want %s", o.Status, justlend.OrderConfirmed)
+	}
+	if rls := d.rentals(t); len(rls) != 1 {
+		t.Errorf("rentals = %+v, want the rental", rls)
+	}
+}
+
+// client returns a client of the gRPC API of the daemon, the calls carry
+// the API key of the daemon.
+func (d *testDaemon) client(t *testing.T) justlendv1.JustLendClient {
+	t.Helper()
+	withKey := func(ctx context.Context) context.Context {
+		if d.apiKey == "" {
+			return ctx
+		}
+		return metadata.AppendToOutgoingContext(ctx, "x-apikey", d.apiKey)
+	}
+	conn, err := grpc.NewClient(d.grpcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(withKey(ctx), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc,
+			cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(withKey(ctx), desc, cc, method, opts...)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return justlendv1.NewJustLendClient(conn)
+}
+
+func TestGRPC(t *testing.T) {
+	d := newTestDaemon(t)
+	c := d.client(t)
+	ctx := context.Background()
+
+	quote, err := c.Quote(ctx, &justlendv1.QuoteRequest{Amount: rentAmount, Type: justlendv1.ResourceType_ENERGY})
+	if err != nil {
+		t.Fatal(err)
+	} else if quote.StakePerTrx != 7223 || quote.OrderId == "" {
+		t.Errorf("quote = %v", quote)
+	}
+
+	rent, err := c.Rent(ctx, &justlendv1.RentRequest{
+		Receiver: d.receiver,
+		Type:     justlendv1.ResourceType_ENERGY,
+		Amount:   rentAmount,
+		Wallet:   d.owner,
+		Wait:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if rent.Receipt.GetStatus() != string(tron.TxConfirmed) {
+		t.Fatalf("rent receipt = %v", rent.Receipt)
+	}
+
+	get := &justlendv1.GetRentalRequest{Renter: d.owner, Receiver: d.receiver, Type: justlendv1.ResourceType_ENERGY}
+	rental, err := c.GetRental(ctx, get)
+	if err != nil {
+		t.Fatal(err)
+	} else if rental.StakePerTrx != rent.StakePerTrx {
+		t.Errorf("rental stake = %d, want %d", rental.StakePerTrx, rent.StakePerTrx)
+	}
+
+	// The watch sends the rental right away & ends once it's returned.
+	watch, err := c.WatchRental(ctx, &justlendv1.WatchRentalRequest{
+		Renter:   d.owner,
+		Receiver: d.receiver,
+		Type:     justlendv1.ResourceType_ENERGY,
+		Interval: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if watched, err := watch.Recv(); err != nil {
+		t.Fatal(err)
+	} else if watched.StakePerTrx != rent.StakePerTrx {
+		t.Errorf("watched stake = %d, want %d", watched.StakePerTrx, rent.StakePerTrx)
+	}
+	ret, err := c.Return(ctx, &justlendv1.ReturnRequest{
+		Receiver: d.receiver,
+		Type:     justlendv1.ResourceType_ENERGY,
+		Wallet:   d.owner,
+		Wait:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	} else if ret.Receipt.GetStatus() != string(tron.TxConfirmed) {
+		t.Fatalf("return receipt = %v", ret.Receipt)
+	}
+	for {
+		if _, err = watch.Recv(); err != nil {
+			break
+		}
+	}
+	if err != io.EOF {
+		t.Errorf("watch ended with %v, want EOF", err)
+	}
+
+	// The domain errors carry the codes of the HTTP API.
+	if _, err = c.GetRental(ctx, get); status.Code(err) != codes.Code(4001) {
+		t.Errorf("GetRental after return = %v, want code 4001", err)
+	}
+	if _, err = c.Rent(ctx, &justlendv1.RentRequest{Receiver: d.receiver, Type: justlendv1.ResourceType_ENERGY}); status.Code(err) != codes.Code(4002) {
+		t.Errorf("Rent without amount = %v, want code 4002", err)
+	}
+	d.node.Fail("feeRatio")
+	if _, err = c.Quote(ctx, &justlendv1.QuoteRequest{Amount: rentAmount, Type: justlendv1.ResourceType_ENERGY}); status.Code(err) != codes.Code(4008) {
+		t.Errorf("Quote of unavailable node = %v, want code 4008", err)
+	}
+
+	// The raw private keys are refused by both transports alike.
+	if _, err = c.Rent(ctx, &justlendv1.RentRequest{
+		Receiver: d.receiver, Type: justlendv1.ResourceType_ENERGY, Amount: rentAmount, PrivateKey: ownerKey,
+	}); status.Code(err) != codes.Code(4005) {
+		t.Errorf("Rent with a raw key = %v, want code 4005", err)
+	}
+	if code := d.do(t, http.MethodPost, "/rent", map[string]interface{}{
+		"receive": d.receiver, "type": 1, "amount": rentAmount, "privateKey": ownerKey,
+	}, nil); code != 4005 {
+		t.Errorf("rent with a raw key: code %d, want 4005", code)
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	d := newTestDaemon(t)
+	fee := fmt.Sprintf("/fee?energy=%d&type=1", rentAmount)
+	rent := map[string]interface{}{
+		"receive": d.receiver,
+		"type":    1,
+		"amount":  rentAmount,
+		"wallet":  d.owner,
+	}
+
+	if code := d.as("").do(t, http.MethodGet, fee, nil, nil); code != 4006 {
+		t.Errorf("fee without a key: code %d, want 4006", code)
+	}
+	if code := d.as("jlk_unknown").do(t, http.MethodGet, fee, nil, nil); code != 4006 {
+		t.Errorf("fee of an unknown key: code %d, want 4006", code)
+	}
+	if _, err := d.as("").client(t).Quote(context.Background(),
+		&justlendv1.QuoteRequest{Amount: rentAmount, Type: justlendv1.ResourceType_ENERGY}); status.Code(err) != codes.Code(4006) {
+		t.Errorf("Quote without a key = %v, want code 4006", err)
+	}
+
+	// A quote key reads the quotes but can't rent.
+	var quoter justlend.APIKeyRL
+	d.mustDo(t, http.MethodPost, "/admin/apikeys", map[string]interface{}{
+		"name":   "quoter",
+		"scopes": []string{"quote"},
+	}, &quoter)
+	if quoter.Key == "" || quoter.Id == "" {
+		t.Fatalf("issued key = %+v", quoter)
+	}
+	q := d.as(quoter.Key)
+	q.mustDo(t, http.MethodGet, fee, nil, nil)
+	if code := q.do(t, http.MethodPost, "/rent", rent, nil); code != 4005 {
+		t.Errorf("rent of a quote key: code %d, want 4005", code)
+	}
+	if code := q.do(t, http.MethodGet, "/admin/apikeys", nil, nil); code != 4005 {
+		t.Errorf("keys of a quote key: code %d, want 4005", code)
+	}
+	if _, err := q.client(t).Rent(context.Background(), &justlendv1.RentRequest{
+		Receiver: d.receiver, Type: justlendv1.ResourceType_ENERGY, Amount: rentAmount, Wallet: d.owner,
+	}); status.Code(err) != codes.Code(4005) {
+		t.Errorf("Rent of a quote key = %v, want code 4005", err)
+	}
+
+	// A rent key only rents to its receivers.
+	var other, renter justlend.APIKeyRL
+	d.mustDo(t, http.MethodPost, "/admin/apikeys", map[string]interface{}{
+		"name":      "other",
+		"scopes":    []string{"rent"},
+		"receivers": []string{d.owner},
+	}, &other)
+	if code := d.as(other.Key).do(t, http.MethodPost, "/rent", rent, nil); code != 4005 {
+		t.Errorf("rent to another receiver: code %d, want 4005", code)
+	}
+	d.mustDo(t, http.MethodPost, "/admin/apikeys", map[string]interface{}{
+		"name":      "renter",
+		"scopes":    []string{"rent"},
+		"wallets":   []string{d.owner},
+		"receivers": []string{d.receiver},
+	}, &renter)
+	r := d.as(renter.Key)
+	r.mustDo(t, http.MethodPost, "/rent", rent, nil)
+	if code := r.do(t, http.MethodPost, "/return", rent, nil); code != 4005 {
+		t.Errorf("return of a rent key: code %d, want 4005", code)
+	}
+
+	var keys struct {
+		Total int                  `json:"total"`
+		Lines []*justlend.APIKeyRL `json:"lines"`
+	}
+	d.mustDo(t, http.MethodGet, "/admin/apikeys", nil, &keys)
+	if keys.Total != 3 || len(keys.Lines) != 3 || keys.Lines[0].Key != "" {
+		t.Errorf("keys = %+v, want the 3 keys without the raw keys", keys)
+	}
+
+	d.mustDo(t, http.MethodDelete, "/admin/apikeys/"+renter.Id, nil, nil)
+	if code := r.do(t, http.MethodGet, fee, nil, nil); code != 4006 {
+		t.Errorf("fee of a revoked key: code %d, want 4006", code)
+	}
+	if code := d.do(t, http.MethodDelete, "/admin/apikeys/"+renter.Id, nil, nil); code != 4001 {
+		t.Errorf("revoke twice: code %d, want 4001", code)
+	}
+}
+
+func TestAPIKeysOrders(t *testing.T) {
+	d := newTestDaemon(t)
+	var rent justlend.RentResourceRL
+	d.mustDo(t, http.MethodPost, "/rent", map[string]interface{}{
+		"receive":  d.receiver,
+		"type":     1,
+		"amount":   rentAmount,
+		"wallet":   d.owner,
+		"duration": 3600,
+	}, &rent)
+
+	type list struct {
+		Total int `json:"total"`
+	}
+	var other, renter justlend.APIKeyRL
+	d.mustDo(t, http.MethodPost, "/admin/apikeys", map[string]interface{}{
+		"name":      "other",
+		"scopes":    []string{"rent", "return"},
+		"receivers": []string{d.owner},
+	}, &other)
+	d.mustDo(t, http.MethodPost, "/admin/apikeys", map[string]interface{}{
+		"name":    "renter",
+		"scopes":  []string{"rent", "return"},
+		"wallets": []string{d.owner},
+	}, &renter)
+
+	// The orders & the schedules of the other receivers are hidden.
+	o := d.as(other.Key)
+	for _, path := range []string{"/orders", "/schedules"} {
+		var l list
+		o.mustDo(t, http.MethodGet, path, nil, &l)
+		if l.Total != 0 {
+			t.Errorf("%s of another receiver: total %d, want 0", path, l.Total)
+		}
+	}
+	for _, path := range []string{"/orders/" + rent.OrderId, "/orders/" + rent.OrderId + "/events"} {
+		if code := o.do(t, http.MethodGet, path, nil, nil); code != 4001 {
+			t.Errorf("%s of another receiver: code %d, want 4001", path, code)
+		}
+	}
+	if code := o.do(t, http.MethodDelete, "/schedules/"+rent.OrderId, nil, nil); code != 4001 {
+		t.Errorf("cancel of another receiver: code %d, want 4001", code)
+	}
+
+	r := d.as(renter.Key)
+	for _, path := range []string{"/orders?kind=rent", "/schedules?status=pending"} {
+		var l list
+		r.mustDo(t, http.MethodGet, path, nil, &l)
+		if l.Total != 1 {
+			t.Errorf("%s of the renter: total %d, want 1", path, l.Total)
+		}
+	}
+	r.mustDo(t, http.MethodGet, "/orders/"+rent.OrderId, nil, nil)
+}
+
+func TestAPIKeysDisabled(t *testing.T) {
+	// The keys are off unless enabled, so that the deployments without an
+	// ADMIN_API_KEY keep starting.
+	for _, enabled := range []string{"false", ""} {
+		t.Run("API_KEY_ENABLED="+enabled, func(t *testing.T) {
+			d := startTestDaemon(t, map[string]string{"API_KEY_ENABLED": enabled, "ADMIN_API_KEY": ""})
+
+			d.mustDo(t, http.MethodGet, fmt.Sprintf("/fee?energy=%d&type=1", rentAmount), nil, nil)
+			d.mustDo(t, http.MethodGet, "/admin/nodes", nil, nil)
+			if _, err := d.client(t).Quote(context.Background(),
+				&justlendv1.QuoteRequest{Amount: rentAmount, Type: justlendv1.ResourceType_ENERGY}); err != nil {
+				t.Errorf("Quote without a key = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"justlend/internal/config"
+	"justlend/internal/wallet"
+	"os"
+	"strings"
+)
+
+// runKeystore runs the keystore subcommand, which manages the encrypted key
+// files unlocked by the daemon:
+//
+//	justlend keystore import [-dir DIR]   reads a hex private key from stdin
+//	justlend keystore list [-dir DIR]     lists the wallets of the keystore
+//
+// The passphrase is resolved from KEYSTORE_PASSWORD(_FILE) like the daemon.
+func runKeystore(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: justlend keystore import|list [-dir DIR]")
+		os.Exit(2)
+	}
+	c := config.Resolve()
+	fs := flag.NewFlagSet("keystore "+args[0], flag.ExitOnError)
+	dir := fs.String("dir", c.KeystoreDir, "keystore directory")
+	_ = fs.Parse(args[1:])
+	if *dir == "" {
+		fatalf("keystore directory required, set -dir or KEYSTORE_DIR")
+	}
+
+	switch args[0] {
+	case "import":
+		fmt.Fprint(os.Stderr, "private key: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			fatalf("read private key: %v", err)
+		}
+		w, err := wallet.Import(*dir, strings.TrimSpace(line), c.KeystorePassword)
+		if err != nil {
+			fatalf("import: %v", err)
+		}
+		fmt.Printf("%s\t%s\n", w.ID, w.Address)
+	case "list":
+		ks, err := wallet.Open(*dir, c.KeystorePassword)
+		if err != nil {
+			fatalf("open: %v", err)
+		}
+		for _, w := range ks.Wallets() {
+			fmt.Printf("%s\t%s\n", w.ID, w.Address)
+		}
+	default:
+		fatalf("unknown keystore command %q", args[0])
+	}
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
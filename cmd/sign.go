@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"justlend/internal/config"
+	"justlend/internal/tron"
+	"justlend/internal/wallet"
+	"os"
+)
+
+// runSign runs the sign subcommand, which signs a transaction exported by
+// /rent/unsigned or /return/unsigned offline with the keystore:
+//
+//	justlend sign [-dir DIR] [-in FILE] [-out FILE]
+//
+// The input is the unsigned object of the export, the output is the body of
+// /broadcast. Both default to the standard streams.
+func runSign(args []string) {
+	c := config.Resolve()
+	fs := flag.NewFlagSet("sign", flag.ExitOnError)
+	dir := fs.String("dir", c.KeystoreDir, "keystore directory")
+	in := fs.String("in", "", "exported transaction file, stdin if empty")
+	out := fs.String("out", "", "signed transaction file, stdout if empty")
+	_ = fs.Parse(args)
+	if *dir == "" {
+		fatalf("keystore directory required, set -dir or KEYSTORE_DIR")
+	}
+
+	r := io.Reader(os.Stdin)
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			fatalf("open: %v", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	var export struct {
+		Owner   string `json:"owner"`
+		TxId    string `json:"txId"`
+		RawData string `json:"rawData"`
+	}
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		fatalf("read transaction: %v", err)
+	}
+	tx, err := tron.DecodeRawData(export.RawData)
+	if err != nil {
+		fatalf("decode transaction: %v", err)
+	}
+	// The txID is the hash of the raw data, so a raw data altered on its way
+	// to the signing machine doesn't match the txID exported.
+	if txId, err := tron.TransactionId(tx); err != nil {
+		fatalf("hash transaction: %v", err)
+	} else if txId != export.TxId {
+		fatalf("txID mismatch: exported %s, raw data hashes to %s", export.TxId, txId)
+	}
+
+	ks, err := wallet.Open(*dir, c.KeystorePassword)
+	if err != nil {
+		fatalf("open keystore: %v", err)
+	}
+	txId, err := tron.SignTransaction(context.Background(), tx, ks, export.Owner)
+	if err != nil {
+		fatalf("sign: %v", err)
+	}
+	signed, err := tron.EncodeTransaction(tx)
+	if err != nil {
+		fatalf("encode transaction: %v", err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fatalf("create: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(map[string]string{"txId": txId, "transaction": signed}); err != nil {
+		fatalf("write transaction: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"justlend/internal/config"
+	"justlend/internal/log"
+	"justlend/internal/remotesigner"
+	"justlend/internal/wallet"
+	"net"
+	"net/http"
+	"time"
+)
+
+// runSigner runs the reference remote signer server, which signs with the
+// wallets of the keystore on behalf of the daemons configured with
+// SIGNER=remote:
+//
+//	justlend signer [-addr ADDR] [-grpc-addr ADDR] [-dir DIR]
+//
+// The gRPC API is served besides the HTTP one if -grpc-addr is set. The
+// requests are authenticated with SIGNER_TOKEN(_FILE) if it's set.
+func runSigner(args []string) {
+	c := config.Resolve()
+	fs := flag.NewFlagSet("signer", flag.ExitOnError)
+	addr := fs.String("addr", c.SignerAddr, "bind address")
+	grpcAddr := fs.String("grpc-addr", c.SignerGRPCAddr, "bind address of the gRPC API, disabled if empty")
+	dir := fs.String("dir", c.KeystoreDir, "keystore directory")
+	_ = fs.Parse(args)
+	if *dir == "" {
+		fatalf("keystore directory required, set -dir or KEYSTORE_DIR")
+	}
+
+	ks, err := wallet.Open(*dir, c.KeystorePassword)
+	if err != nil {
+		fatalf("open keystore: %v", err)
+	}
+	if c.SignerToken == "" {
+		log.Warn("SIGNER_TOKEN not set, the signer accepts unauthenticated requests")
+	}
+	srv := &http.Server{
+		Addr:         *addr,
+		Handler:      remotesigner.NewHandler(ks, c.SignerToken),
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+	}
+	if *grpcAddr != "" {
+		ln, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			fatalf("listen %s: %v", *grpcAddr, err)
+		}
+		gs := remotesigner.NewGRPCServer(ks, c.SignerToken)
+		log.InfoW("Running remote signer", "transport", "gRPC", "addr", *grpcAddr)
+		go func() { log.FatalW("signer stopped", "error", gs.Serve(ln)) }()
+	}
+	log.InfoW("Running remote signer", "addr", *addr, "wallets", len(ks.Wallets()))
+	log.FatalW("signer stopped", "error", srv.ListenAndServe())
+}
@@ -0,0 +1,365 @@
+// Package abi is a Tron-aware wrapper of the Ethereum contract ABI, it encodes
+// the contract calls & decodes the outputs and events of the TVM, which shares
+// the ABI of the EVM except that the addresses are Tron addresses. The Tron
+// addresses are accepted as the inputs, i.e. internal.Address or a base58/hex
+// string, and the decoded addresses are always of type internal.Address.
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"io"
+	"justlend/internal"
+	"justlend/internal/protos/core"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// ABI holds the parsed contract ABI.
+type ABI struct {
+	ethabi.ABI
+}
+
+// JSON parses the JSON ABI of a contract, both the standard ABI of solc and
+// the ABI returned by the Tron nodes, i.e. upper-cased entry types and the
+// `trcToken` type, are accepted.
+func JSON(r io.Reader) (*ABI, error) {
+	var entries []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		normalize(e)
+	}
+	blob, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	a, err := ethabi.JSON(bytes.NewReader(blob))
+	if err != nil {
+		return nil, err
+	}
+	return &ABI{ABI: a}, nil
+}
+
+// MustJSON is like JSON but panics if the ABI cannot be parsed, it simplifies
+// the initialization of the global variables holding the ABI.
+func MustJSON(s string) *ABI {
+	a, err := JSON(strings.NewReader(s))
+	if err != nil {
+		panic(fmt.Sprintf("abi: %v", err))
+	}
+	return a
+}
+
+// normalize converts the Tron flavor of an ABI entry into the standard one.
+func normalize(e map[string]interface{}) {
+	for _, k := range []string{"type", "stateMutability"} {
+		if v, ok := e[k].(string); ok {
+			e[k] = strings.ToLower(v)
+		}
+	}
+	for _, k := range []string{"inputs", "outputs", "components"} {
+		args, _ := e[k].([]interface{})
+		for _, a := range args {
+			if m, ok := a.(map[string]interface{}); ok {
+				if t, ok := m["type"].(string); ok {
+					// trcToken is the token id of the TRC10 tokens.
+					m["type"] = strings.Replace(t, "trcToken", "uint256", 1)
+				}
+				normalize(m)
+			}
+		}
+	}
+}
+
+// Method is a contract method.
+type Method struct {
+	ethabi.Method
+}
+
+// ParseMethod parses the method of the signature, i.e. `transfer(address,uint256)`,
+// whose outputs are of the given types, i.e. `bool`.
+func ParseMethod(signature string, outputs ...string) (*Method, error) {
+	sel, err := ethabi.ParseSelector(signature)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := newArguments(sel.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outs := make([]ethabi.ArgumentMarshaling, 0, len(outputs))
+	for _, o := range outputs {
+		outs = append(outs, ethabi.ArgumentMarshaling{Type: o})
+	}
+	results, err := newArguments(outs)
+	if err != nil {
+		return nil, err
+	}
+	m := ethabi.NewMethod(sel.Name, sel.Name, ethabi.Function, "", false, false, inputs, results)
+	return &Method{Method: m}, nil
+}
+
+// MustParseMethod is like ParseMethod but panics if the signature is invalid.
+func MustParseMethod(signature string, outputs ...string) *Method {
+	m, err := ParseMethod(signature, outputs...)
+	if err != nil {
+		panic(fmt.Sprintf("abi: %v", err))
+	}
+	return m
+}
+
+func newArguments(ms []ethabi.ArgumentMarshaling) (ethabi.Arguments, error) {
+	args := make(ethabi.Arguments, 0, len(ms))
+	for _, m := range ms {
+		t, err := ethabi.NewType(m.Type, m.InternalType, m.Components)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, ethabi.Argument{Name: m.Name, Type: t})
+	}
+	return args, nil
+}
+
+// Pack encodes the call data of the method, which is the selector followed
+// by the encoded arguments.
+func (m *Method) Pack(args ...interface{}) ([]byte, error) {
+	converted, err := toEthValues(m.Inputs, args)
+	if err != nil {
+		return nil, fmt.Errorf("abi: %s: %w", m.Name, err)
+	}
+	data, err := m.Inputs.Pack(converted...)
+	if err != nil {
+		return nil, fmt.Errorf("abi: %s: %w", m.Name, err)
+	}
+	return append(append([]byte{}, m.ID...), data...), nil
+}
+
+// Unpack decodes the outputs of the method.
+func (m *Method) Unpack(data []byte) ([]interface{}, error) {
+	vs, err := m.Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("abi: %s: %w", m.Name, err)
+	}
+	return fromEthValues(vs), nil
+}
+
+// Method returns the method of the given name.
+func (a *ABI) Method(name string) (*Method, error) {
+	m, ok := a.Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("abi: method %q not found", name)
+	}
+	return &Method{Method: m}, nil
+}
+
+// Pack encodes the call data of the named method.
+func (a *ABI) Pack(name string, args ...interface{}) ([]byte, error) {
+	m, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Pack(args...)
+}
+
+// Unpack decodes the outputs of the named method.
+func (a *ABI) Unpack(name string, data []byte) ([]interface{}, error) {
+	m, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.Unpack(data)
+}
+
+// UnpackRevert decodes the reason of the revert data, i.e. the solidity
+// `Error(string)` or `Panic(uint256)` outputs, it fails if the data is neither.
+func UnpackRevert(data []byte) (string, error) {
+	return ethabi.UnpackRevert(data)
+}
+
+// Event is a decoded event of a transaction log.
+type Event struct {
+	// Name is the name of the event.
+	Name string
+	// Values holds both indexed & non-indexed arguments by name.
+	Values map[string]interface{}
+}
+
+// DecodeEvent decodes the log emitted by the contract.
+func (a *ABI) DecodeEvent(log *core.TransactionInfo_Log) (*Event, error) {
+	if len(log.GetTopics()) == 0 {
+		return nil, fmt.Errorf("abi: anonymous event")
+	}
+	ev, err := a.EventByID(common.BytesToHash(log.GetTopics()[0]))
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(ev.Inputs))
+	if err = ev.Inputs.NonIndexed().UnpackIntoMap(values, log.GetData()); err != nil {
+		return nil, fmt.Errorf("abi: %s: %w", ev.Name, err)
+	}
+	var indexed ethabi.Arguments
+	for _, arg := range ev.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	topics := make([]common.Hash, 0, len(log.GetTopics())-1)
+	for _, t := range log.GetTopics()[1:] {
+		topics = append(topics, common.BytesToHash(t))
+	}
+	if err = ethabi.ParseTopicsIntoMap(values, indexed, topics); err != nil {
+		return nil, fmt.Errorf("abi: %s: %w", ev.Name, err)
+	}
+	for k, v := range values {
+		values[k] = fromEth(v)
+	}
+	return &Event{Name: ev.Name, Values: values}, nil
+}
+
+// toEthValues converts the arguments into the Go types expected by the
+// Ethereum ABI encoder of the argument types.
+func toEthValues(args ethabi.Arguments, vs []interface{}) ([]interface{}, error) {
+	if len(args) != len(vs) {
+		return nil, fmt.Errorf("argument count mismatch: got %d for %d", len(vs), len(args))
+	}
+	out := make([]interface{}, len(vs))
+	for i, v := range vs {
+		c, err := toEth(args[i].Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+func toEth(t ethabi.Type, v interface{}) (interface{}, error) {
+	switch t.T {
+	case ethabi.AddressTy:
+		return ToEthAddress(v)
+	case ethabi.IntTy, ethabi.UintTy:
+		return toEthInt(t, v)
+	case ethabi.SliceTy, ethabi.ArrayTy:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return v, nil
+		}
+		out := reflect.New(t.GetType()).Elem()
+		if t.T == ethabi.SliceTy {
+			out = reflect.MakeSlice(t.GetType(), rv.Len(), rv.Len())
+		} else if rv.Len() != t.Size {
+			return nil, fmt.Errorf("array length mismatch: got %d for %d", rv.Len(), t.Size)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			e, err := toEth(*t.Elem, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			out.Index(i).Set(reflect.ValueOf(e))
+		}
+		return out.Interface(), nil
+	default:
+		return v, nil
+	}
+}
+
+// toEthInt converts any Go integer into the exact integer type expected by
+// the encoder, which is *big.Int for the integers wider than 64 bits.
+func toEthInt(t ethabi.Type, v interface{}) (interface{}, error) {
+	var b *big.Int
+	switch n := v.(type) {
+	case *big.Int:
+		b = n
+	case big.Int:
+		b = &n
+	default:
+		rv := reflect.ValueOf(v)
+		switch {
+		case rv.CanInt():
+			b = big.NewInt(rv.Int())
+		case rv.CanUint():
+			b = new(big.Int).SetUint64(rv.Uint())
+		default:
+			return v, nil
+		}
+	}
+	target := t.GetType()
+	if target == reflect.TypeOf(b) {
+		return b, nil
+	}
+	out := reflect.New(target).Elem()
+	if t.T == ethabi.IntTy {
+		if !b.IsInt64() || out.OverflowInt(b.Int64()) {
+			return nil, fmt.Errorf("%v overflows %s", b, t)
+		}
+		out.SetInt(b.Int64())
+	} else {
+		if b.Sign() < 0 || !b.IsUint64() || out.OverflowUint(b.Uint64()) {
+			return nil, fmt.Errorf("%v overflows %s", b, t)
+		}
+		out.SetUint(b.Uint64())
+	}
+	return out.Interface(), nil
+}
+
+// ToEthAddress converts a Tron address into the 20 bytes address of the ABI,
+// v may be an internal.Address, a base58 or hex string, or a common.Address.
+func ToEthAddress(v interface{}) (common.Address, error) {
+	var b []byte
+	switch a := v.(type) {
+	case common.Address:
+		return a, nil
+	case internal.Address:
+		b = a
+	case []byte:
+		b = a
+	case string:
+		if internal.IsValidAddress(a) {
+			b = internal.DecodeCheck(a)
+		} else if h, err := internal.FromHex(a); err == nil {
+			b = h
+		}
+	}
+	switch {
+	case len(b) == internal.AddressLength && b[0] == internal.TronBytePrefix:
+		return common.BytesToAddress(b[1:]), nil
+	case len(b) == common.AddressLength:
+		return common.BytesToAddress(b), nil
+	default:
+		return common.Address{}, fmt.Errorf("invalid address %v", v)
+	}
+}
+
+// FromEthAddress converts the 20 bytes address of the ABI into a Tron address.
+func FromEthAddress(a common.Address) internal.Address {
+	return append(internal.Address{internal.TronBytePrefix}, a.Bytes()...)
+}
+
+func fromEthValues(vs []interface{}) []interface{} {
+	for i, v := range vs {
+		vs[i] = fromEth(v)
+	}
+	return vs
+}
+
+// fromEth converts the decoded addresses into Tron addresses.
+func fromEth(v interface{}) interface{} {
+	switch a := v.(type) {
+	case common.Address:
+		return FromEthAddress(a)
+	case []common.Address:
+		out := make([]internal.Address, len(a))
+		for i := range a {
+			out[i] = FromEthAddress(a[i])
+		}
+		return out
+	default:
+		return v
+	}
+}
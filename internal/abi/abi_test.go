@@ -0,0 +1,192 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/common"
+	"justlend/internal"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	// usdt is the USDT contract of the mainnet in the base58 & hex forms,
+	// usdtEth is its address in the EVM form.
+	usdt    = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	usdtHex = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	usdtEth = "a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+)
+
+// words joins the hex words of the encoded data.
+func words(ws ...string) []byte {
+	b, err := hex.DecodeString(strings.Join(ws, ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// word left pads the hex value to a 32 bytes word.
+func word(v string) string { return strings.Repeat("0", 64-len(v)) + v }
+
+func TestMethodPack(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		args      []interface{}
+		want      []byte
+		wantErr   bool
+	}{
+		{name: "transfer base58", signature: "transfer(address,uint256)",
+			args: []interface{}{usdt, big.NewInt(1000000)},
+			want: words("a9059cbb", word(usdtEth), word("f4240"))},
+		{name: "transfer hex", signature: "transfer(address,uint256)",
+			args: []interface{}{usdtHex, int64(1000000)},
+			want: words("a9059cbb", word(usdtEth), word("f4240"))},
+		{name: "transfer address", signature: "transfer(address,uint256)",
+			args: []interface{}{internal.Address(internal.DecodeCheck(usdt)), uint64(1000000)},
+			want: words("a9059cbb", word(usdtEth), word("f4240"))},
+		{name: "balanceOf", signature: "balanceOf(address)",
+			args: []interface{}{usdt},
+			want: words("70a08231", word(usdtEth))},
+		{name: "approve max", signature: "approve(address,uint256)",
+			args: []interface{}{usdt, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))},
+			want: words("095ea7b3", word(usdtEth), strings.Repeat("f", 64))},
+		{name: "invalid address", signature: "balanceOf(address)", args: []interface{}{"T123"}, wantErr: true},
+		{name: "argument count", signature: "balanceOf(address)", args: []interface{}{usdt, 1}, wantErr: true},
+		{name: "overflow", signature: "f(uint8)", args: []interface{}{256}, wantErr: true},
+		{name: "negative uint", signature: "f(uint64)", args: []interface{}{-1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MustParseMethod(tt.signature).Pack(tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Pack() error = %v, wantErr %v", err, tt.wantErr)
+			} else if !bytes.Equal(got, tt.want) {
+				t.Errorf("Pack() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodUnpack(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs []string
+		data    []byte
+		want    []interface{}
+		wantErr bool
+	}{
+		{name: "uint256", outputs: []string{"uint256"}, data: words(word("f4240")),
+			want: []interface{}{big.NewInt(1000000)}},
+		{name: "address", outputs: []string{"address"}, data: words(word(usdtEth)),
+			want: []interface{}{internal.Address(internal.DecodeCheck(usdt))}},
+		{name: "bool", outputs: []string{"bool", "bool"}, data: words(word("1"), word("0")),
+			want: []interface{}{true, false}},
+		{name: "invalid bool", outputs: []string{"bool"}, data: words(word("2")), wantErr: true},
+		{name: "short", outputs: []string{"uint256"}, data: words("0f4240"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MustParseMethod("f()", tt.outputs...).Unpack(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unpack() error = %v, wantErr %v", err, tt.wantErr)
+			} else if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unpack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRoundTrip packs the inputs & unpacks them as the outputs of the same
+// types, the addresses come back in the Tron form.
+func TestRoundTrip(t *testing.T) {
+	types := []string{"address", "uint256", "bool", "address[]"}
+	m := MustParseMethod("f(address,uint256,bool,address[])", types...)
+	receiver := internal.Address(internal.DecodeCheck(usdt))
+	data, err := m.Pack(usdt, big.NewInt(65000), true, []string{usdt, usdtHex})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := m.Unpack(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{receiver, big.NewInt(65000), true, []internal.Address{receiver, receiver}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unpack(Pack()) = %v, want %v", got, want)
+	}
+	if got[0].(internal.Address).String() != usdt {
+		t.Errorf("address = %s, want %s", got[0], usdt)
+	}
+}
+
+func TestToEthAddress(t *testing.T) {
+	eth := common.HexToAddress(usdtEth)
+	tests := []struct {
+		name    string
+		v       interface{}
+		wantErr bool
+	}{
+		{name: "base58", v: usdt},
+		{name: "hex", v: usdtHex},
+		{name: "hex 0x", v: "0x" + usdtHex},
+		{name: "evm hex", v: usdtEth},
+		{name: "address", v: internal.Address(internal.DecodeCheck(usdt))},
+		{name: "bytes", v: eth.Bytes()},
+		{name: "common", v: eth},
+		{name: "bad prefix", v: "42" + usdtEth, wantErr: true},
+		{name: "bad checksum", v: usdt[:len(usdt)-1] + "u", wantErr: true},
+		{name: "int", v: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToEthAddress(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToEthAddress() error = %v, wantErr %v", err, tt.wantErr)
+			} else if !tt.wantErr && got != eth {
+				t.Errorf("ToEthAddress() = %s, want %s", got, eth)
+			}
+		})
+	}
+	if got := FromEthAddress(eth); got.String() != usdt || hex.EncodeToString(got) != usdtHex {
+		t.Errorf("FromEthAddress() = %s %x, want %s %s", got, []byte(got), usdt, usdtHex)
+	}
+}
+
+func TestUnpackRevert(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "error", data: words("08c379a0", word("20"), word("4"), "6e6f7065"+strings.Repeat("0", 56)),
+			want: "nope"},
+		{name: "panic", data: words("4e487b71", word("11")), want: "arithmetic underflow or overflow"},
+		{name: "unknown selector", data: words("deadbeef", word("20")), wantErr: true},
+		{name: "short", data: words("08c3"), wantErr: true},
+		{name: "truncated", data: words("08c379a0", word("20"), word("40"), "6e6f7065"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnpackRevert(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnpackRevert() error = %v, wantErr %v", err, tt.wantErr)
+			} else if got != tt.want {
+				t.Errorf("UnpackRevert() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The reverts of the contracts decode alike.
+	data, err := MustParseMethod("Error(string)").Pack("insufficient security deposit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := UnpackRevert(data); err != nil || got != "insufficient security deposit" {
+		t.Errorf("UnpackRevert(Error()) = %q, %v", got, err)
+	}
+}
@@ -18,13 +18,21 @@ func GetEnv(key, fallback string) string {
 	return fallback
 }
 
-// GetEnvBool looks up the given key from the environment, returning true if
-// it exists and value is equals to `TRUE`, and otherwise returning the
-// given fallback value.
+// GetEnvBool looks up the given key from the environment and expects a
+// boolean as accepted by strconv.ParseBool, returning the boolean value if it
+// exists, and otherwise returning the given fallback value.
+//
+// A value that can't be parsed as a boolean is tolerated as it always was:
+// GetEnvBool logs it & returns the fallback value, so only an explicit false
+// changes the outcome of the settings defaulting to true.
 func GetEnvBool(key string, fallback bool) bool {
-	if value, ok := os.LookupEnv(key); ok &&
-		strings.ToUpper(value) == "TRUE" {
-		return true
+	if s, ok := os.LookupEnv(key); ok {
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			log.Printf("getEnvBool: bad value %q for %s, using %t: %v", s, key, fallback, err)
+			return fallback
+		}
+		return v
 	}
 	return fallback
 }
@@ -107,12 +115,43 @@ func GetEnvHexBytes(key string, fallback string) []byte {
 	return v
 }
 
+// GetEnvSecret looks up the given key from the environment like GetEnv, but
+// if the `<key>_FILE` variable exists, the content of the file it points to
+// is returned instead, so that secrets need not be kept in the environment.
+//
+// If the file can't be read, GetEnvSecret terminates the program.
+func GetEnvSecret(key, fallback string) string {
+	if f, ok := os.LookupEnv(key + "_FILE"); ok {
+		v, err := os.ReadFile(f)
+		if err != nil {
+			log.Fatalf("getEnvSecret: bad file %q for %s: %v", f, key, err)
+		}
+		return strings.TrimRight(string(v), "\r\n")
+	}
+	return GetEnv(key, fallback)
+}
+
+// GetEnvList wraps GetEnvSecret and splits the value by comma, the empty
+// elements are dropped.
+func GetEnvList(key, fallback string) []string {
+	var vs []string
+	for _, v := range strings.Split(GetEnvSecret(key, fallback), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
 // Config holds shared configuration values used in instantiating
 // our server components.
 type Config struct {
 
 	// - Addr used for trxEnergy hosting.
 	Addr string
+	// GRPCAddr is the bind address of the gRPC API, which is not served
+	// if it's empty.
+	GRPCAddr string
 
 	// The duration for which the server gracefully wait for existing
 	// connections to finish - e.g. 15s or 1m
@@ -123,6 +162,42 @@ type Config struct {
 
 	// Keys used for secure cookie encryption.
 	SCHashKey, SCBlockKey []byte
+
+	// APIKeyEnabled requires the requests to carry an API key, AdminAPIKey
+	// is the root key which issues the others.
+	APIKeyEnabled bool
+	AdminAPIKey   string
+
+	// KeystoreDir is the directory of the encrypted key files, which are
+	// unlocked with KeystorePassword at the daemon start. No keystore
+	// is opened if the directory is empty.
+	KeystoreDir, KeystorePassword string
+
+	// Signer selects the signer of the wallets, one of `keystore`, `local`
+	// & `remote`. The keystore signer is used by default.
+	Signer string
+	// SignerURL & SignerToken locate & authenticate the remote signer, the
+	// grpc:// & grpcs:// URLs select its gRPC transport.
+	SignerURL, SignerToken string
+	// SignerKeys are the hex private keys held by the local signer.
+	SignerKeys []string
+	// SignerAddr & SignerGRPCAddr are the bind addresses of the reference
+	// signer server, its gRPC API is disabled if SignerGRPCAddr is empty.
+	SignerAddr, SignerGRPCAddr string
+
+	// DBDriver selects the database of the ledger, `sqlite` or `postgres`,
+	// DBSource is the path of the SQLite file or the Postgres connection string.
+	DBDriver, DBSource string
+	// ReconcileInterval is the interval between two settlements of the
+	// broadcast orders.
+	ReconcileInterval time.Duration
+	// ReturnInterval is the interval between two checks of the scheduled returns.
+	ReturnInterval time.Duration
+	// WatchInterval is the interval between two checks of the deposits.
+	WatchInterval time.Duration
+	// MaintainInterval is the interval between two checks of the energy
+	// of the maintained receivers.
+	MaintainInterval time.Duration
 }
 
 const (
@@ -141,11 +216,32 @@ func Resolve() *Config {
 	return &Config{
 		// Resolve host information.
 		Addr:            GetEnv("MS_ADDR", ":8085"),
+		GRPCAddr:        GetEnv("GRPC_ADDR", ":8086"),
 		Domain:          GetEnv("DOMAIN", ""),
 		GracefulTimeout: time.Duration(GetEnvInt("GRACEFUL_TIMEOUT", 15)) * time.Second,
 		// Resolve http cookie hash & block keys.
 		SCHashKey:  GetEnvHexBytes("SESSION_HASH_KEY", defaultSCHashKey),
 		SCBlockKey: GetEnvHexBytes("SESSION_BLOCK_KEY", defaultSCBlockKey),
+		// Resolve API key settings.
+		APIKeyEnabled: GetEnvBool("API_KEY_ENABLED", false),
+		AdminAPIKey:   GetEnvSecret("ADMIN_API_KEY", ""),
+		// Resolve keystore location & passphrase.
+		KeystoreDir:      GetEnv("KEYSTORE_DIR", ""),
+		KeystorePassword: GetEnvSecret("KEYSTORE_PASSWORD", ""),
+		// Resolve signer settings.
+		Signer:         GetEnv("SIGNER", "keystore"),
+		SignerURL:      GetEnv("SIGNER_URL", ""),
+		SignerToken:    GetEnvSecret("SIGNER_TOKEN", ""),
+		SignerKeys:     GetEnvList("SIGNER_PRIVATE_KEYS", ""),
+		SignerAddr:     GetEnv("SIGNER_ADDR", ":8090"),
+		SignerGRPCAddr: GetEnv("SIGNER_GRPC_ADDR", ""),
+		// Resolve ledger settings.
+		DBDriver:          GetEnv("DB_DRIVER", "sqlite"),
+		DBSource:          GetEnvSecret("DB_SOURCE", "justlend.db"),
+		ReconcileInterval: GetEnvDuration("LEDGER_RECONCILE_INTERVAL", 30) * time.Second,
+		ReturnInterval:    GetEnvDuration("RETURN_SCHEDULER_INTERVAL", 30) * time.Second,
+		WatchInterval:     GetEnvDuration("WATCHDOG_INTERVAL", 60) * time.Second,
+		MaintainInterval:  GetEnvDuration("MAINTAIN_INTERVAL", 60) * time.Second,
 	}
 }
 
@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestGetEnvBool(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		set      bool
+		fallback bool
+		want     bool
+	}{
+		{name: "unset", fallback: true, want: true},
+		{name: "unset false", fallback: false, want: false},
+		{name: "false", value: "false", set: true, fallback: true, want: false},
+		{name: "FALSE", value: "FALSE", set: true, fallback: true, want: false},
+		{name: "zero", value: "0", set: true, fallback: true, want: false},
+		{name: "true", value: "true", set: true, fallback: false, want: true},
+		{name: "TRUE", value: "TRUE", set: true, fallback: false, want: true},
+		{name: "one", value: "1", set: true, fallback: false, want: true},
+		// The unparsable values fall back, as they always did.
+		{name: "bad true", value: "yes", set: true, fallback: true, want: true},
+		{name: "bad false", value: "yes", set: true, fallback: false, want: false},
+		{name: "empty", value: "", set: true, fallback: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv("TEST_BOOL", tt.value)
+			}
+			if got := GetEnvBool("TEST_BOOL", tt.fallback); got != tt.want {
+				t.Errorf("GetEnvBool(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
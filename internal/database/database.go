@@ -0,0 +1,166 @@
+// Package database adds some useful functionality to a sql.DB, it's
+// independent of the schema and supports both SQLite & Postgres. The
+// queries are written with the `$n` placeholders, which both accept.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"justlend/internal/config"
+	"justlend/internal/derrors"
+	_ "modernc.org/sqlite"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SQLite is the default driver, the source is the path of the database file.
+	SQLite = "sqlite"
+	// Postgres is the driver of Postgres, the source is a connection string.
+	Postgres = "postgres"
+)
+
+// conn is implemented by both *sql.DB & *sql.Tx.
+type conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// DB wraps a sql.DB, or a sql.Tx inside a transaction.
+type DB struct {
+	db     *sql.DB
+	tx     *sql.Tx
+	driver string
+}
+
+// Open opens the database of the driver, one of SQLite & Postgres.
+func Open(driver, source string) (_ *DB, err error) {
+	defer derrors.Wrap(&err, "database.Open(%q)", driver)
+
+	var db *sql.DB
+	switch driver {
+	case SQLite:
+		if db, err = sql.Open(SQLite, sqliteSource(source)); err != nil {
+			return nil, err
+		}
+		// A single connection serializes the writers, which avoids the
+		// SQLITE_BUSY errors of the concurrent transactions.
+		db.SetMaxOpenConns(1)
+	case Postgres:
+		c, err := pgx.ParseConfig(source)
+		if err != nil {
+			return nil, err
+		}
+		c.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10)
+		db = stdlib.OpenDB(*c)
+	default:
+		return nil, fmt.Errorf("unknown driver")
+	}
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &DB{db: db, driver: driver}, nil
+}
+
+// sqliteSource enables the foreign keys & waits for the locks held by the
+// other processes rather than failing immediately.
+func sqliteSource(path string) string {
+	if strings.Contains(path, "_pragma=") {
+		return path
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+// Driver returns the driver of the database.
+func (db *DB) Driver() string { return db.driver }
+
+// Close closes the database.
+func (db *DB) Close() error { return db.db.Close() }
+
+func (db *DB) conn() conn {
+	if db.tx != nil {
+		return db.tx
+	}
+	return db.db
+}
+
+// Exec executes a SQL statement and returns the number of rows it affected.
+func (db *DB) Exec(ctx context.Context, query string, args ...any) (_ int64, err error) {
+	res, err := db.conn().ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// QueryRow runs the query and returns a single row.
+func (db *DB) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.conn().QueryRowContext(ctx, query, args...)
+}
+
+// RunQuery executes the query and calls f on each row.
+func (db *DB) RunQuery(ctx context.Context, query string, f func(*sql.Rows) error, args ...any) error {
+	rows, err := db.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = f(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Transact executes the given function in the context of a SQL transaction,
+// the transaction is committed if f returns nil and rolled back otherwise.
+// Transact may be called inside a transaction, f then joins the transaction.
+func (db *DB) Transact(ctx context.Context, opts *sql.TxOptions, f func(*DB) error) (err error) {
+	if db.tx != nil {
+		return f(db)
+	}
+	tx, err := db.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("db.BeginTx(): %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else if err = tx.Commit(); err != nil {
+			err = fmt.Errorf("tx.Commit(): %w", err)
+		}
+	}()
+	return f(&DB{db: db.db, tx: tx, driver: db.driver})
+}
+
+// Migrate executes the statements of the schema one by one, the statements
+// are separated by semicolons & must be idempotent, i.e. `CREATE TABLE IF
+// NOT EXISTS`, since they're executed on every start.
+func (db *DB) Migrate(ctx context.Context, schema string) (err error) {
+	defer derrors.Wrap(&err, "db.Migrate()")
+
+	return db.Transact(ctx, nil, func(tx *DB) error {
+		for _, stmt := range strings.Split(schema, ";") {
+			if stmt = strings.TrimSpace(stmt); stmt == "" {
+				continue
+			}
+			if _, err := tx.Exec(ctx, stmt); err != nil {
+				return fmt.Errorf("%w: %s", err, stmt)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,189 @@
+package justlend
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/google/uuid"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"strings"
+	"time"
+)
+
+// APIKeyScope is a permission of an API key.
+type APIKeyScope string
+
+const (
+	// ScopeQuote reads the quotes, the rentals & the transactions.
+	ScopeQuote APIKeyScope = "quote"
+	// ScopeRent rents the resources & manages the scheduled returns.
+	ScopeRent APIKeyScope = "rent"
+	// ScopeReturn returns the rented resources.
+	ScopeReturn APIKeyScope = "return"
+	// ScopeAdmin grants every scope, along with the watchdog, the maintained
+	// energy, the transfers & the admin routes.
+	ScopeAdmin APIKeyScope = "admin"
+)
+
+// APIKeyMeta issues an API key.
+type APIKeyMeta struct {
+	Name   string        `json:"name"`
+	Scopes []APIKeyScope `json:"scopes"`
+	// Wallets & Receivers restrict the wallets paying for the requests of
+	// the key & the receivers of the rentals, either is unrestricted if
+	// it's empty. The wallets are the IDs or the addresses of the signer,
+	// or the owners of the unsigned transactions.
+	Wallets   []string `json:"wallets"`
+	Receivers []string `json:"receivers"`
+}
+
+// maxAPIKeyName is the maximum length of the name of an API key.
+const maxAPIKeyName = 64
+
+func (m *APIKeyMeta) Conform(_ context.Context) error {
+	switch {
+	case internal.IsEmpty(m.Name) || len(m.Name) > maxAPIKeyName:
+		return derrors.InvalidParam
+	case len(m.Scopes) == 0:
+		return derrors.InvalidParam
+	}
+	for _, s := range m.Scopes {
+		if !internal.Contains(s, ScopeQuote, ScopeRent, ScopeReturn, ScopeAdmin) {
+			return derrors.InvalidParam
+		}
+	}
+	for _, w := range m.Wallets {
+		// The lists are stored comma separated.
+		if internal.IsEmpty(w) || strings.Contains(w, ",") {
+			return derrors.InvalidParam
+		}
+	}
+	for _, r := range m.Receivers {
+		if !internal.IsValidAddress(r) {
+			return derrors.InvalidParam
+		}
+	}
+	return nil
+}
+
+type APIKeyRL struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	// Key is the raw key, which is only returned once when it's issued,
+	// the ledger keeps its hash.
+	Key       string        `json:"key,omitempty"`
+	Scopes    []APIKeyScope `json:"scopes"`
+	Wallets   []string      `json:"wallets"`
+	Receivers []string      `json:"receivers"`
+	CreatedAt time.Time     `json:"createdAt"`
+	RevokedAt *time.Time    `json:"revokedAt,omitempty"`
+}
+
+// Allows reports whether the key has any of the scopes.
+func (k *APIKeyRL) Allows(scopes ...APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == ScopeAdmin || internal.Contains(s, scopes...) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsWallet reports whether the requests of the key may be paid by the
+// wallet, the raw private keys are only allowed to the unrestricted keys.
+func (k *APIKeyRL) AllowsWallet(wallet string) bool {
+	return len(k.Wallets) == 0 || internal.Contains(wallet, k.Wallets...)
+}
+
+// AllowsReceiver reports whether the key may rent to the receiver.
+func (k *APIKeyRL) AllowsReceiver(receiver string) bool {
+	return len(k.Receivers) == 0 || internal.Contains(receiver, k.Receivers...)
+}
+
+type apiKeyContextKey struct{}
+
+// WithAPIKey returns the context of a request authenticated by the key.
+func WithAPIKey(ctx context.Context, k *APIKeyRL) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, k)
+}
+
+// APIKeyFrom returns the key authenticating the request of the context.
+func APIKeyFrom(ctx context.Context) (*APIKeyRL, bool) {
+	k, ok := ctx.Value(apiKeyContextKey{}).(*APIKeyRL)
+	return k, ok
+}
+
+// conformAccess returns derrors.Forbidden if the API key of the request is
+// not allowed the wallet or the receiver, an empty receiver is not checked.
+// The requests without a key are not restricted.
+func conformAccess(ctx context.Context, wallet, receiver string) error {
+	k, ok := APIKeyFrom(ctx)
+	switch {
+	case !ok:
+		return nil
+	case !k.AllowsWallet(wallet):
+		return derrors.Forbidden
+	case receiver != "" && !k.AllowsReceiver(receiver):
+		return derrors.Forbidden
+	default:
+		return nil
+	}
+}
+
+// RootAPIKeyId is the ID of the root key of the config.
+const RootAPIKeyId = "root"
+
+// Authenticate returns the API key of the raw key of a request. The root key
+// is an unrestricted admin key, which is configured rather than issued.
+func Authenticate(ctx context.Context, s APIKeyService, root, key string) (*APIKeyRL, error) {
+	if internal.IsEmpty(key) {
+		return nil, derrors.Unauthenticated
+	}
+	if root != "" && subtle.ConstantTimeCompare([]byte(key), []byte(root)) == 1 {
+		return &APIKeyRL{Id: RootAPIKeyId, Name: RootAPIKeyId, Scopes: []APIKeyScope{ScopeAdmin}}, nil
+	}
+	return s.Authenticate(ctx, key)
+}
+
+type APIKeyIdMeta struct {
+	Id string
+}
+
+func (m *APIKeyIdMeta) Conform(_ context.Context) error {
+	if _, err := uuid.Parse(m.Id); err != nil {
+		return derrors.InvalidParam
+	}
+	return nil
+}
+
+type APIKeysMeta struct {
+	// Offset & Limit select the page of the keys.
+	Offset, Limit uint64
+}
+
+func (m *APIKeysMeta) Conform(_ context.Context) error {
+	if m.Limit > maxOrderLimit {
+		return derrors.InvalidParam
+	} else if m.Limit == 0 {
+		m.Limit = 20
+	}
+	return nil
+}
+
+var (
+	_ internal.Conformer = (*APIKeyMeta)(nil)
+	_ internal.Conformer = (*APIKeyIdMeta)(nil)
+	_ internal.Conformer = (*APIKeysMeta)(nil)
+)
+
+type APIKeyService interface {
+	// IssueAPIKey issues a new key, whose raw key is only returned here.
+	IssueAPIKey(ctx context.Context, req *APIKeyMeta) (*APIKeyRL, error)
+	// APIKeys returns the page of the keys along with the total count.
+	APIKeys(ctx context.Context, req *APIKeysMeta) ([]*APIKeyRL, int, error)
+	// RevokeAPIKey revokes the key, which fails to authenticate then.
+	RevokeAPIKey(ctx context.Context, req *APIKeyIdMeta) error
+	// Authenticate returns the issued key of the raw key, it fails with
+	// derrors.Unauthenticated if the key is unknown or revoked.
+	Authenticate(ctx context.Context, key string) (*APIKeyRL, error)
+}
@@ -0,0 +1,43 @@
+package justlend
+
+import (
+	"context"
+	"encoding/hex"
+	"justlend/internal"
+	"justlend/internal/derrors"
+)
+
+// BroadcastMeta broadcasts a transaction signed offline, i.e. an unsigned
+// rent or return signed by `justlend sign`.
+type BroadcastMeta struct {
+	// Transaction is the hex encoded signed transaction.
+	Transaction string `json:"transaction"`
+	// Wait blocks the request until the transaction is final.
+	Wait bool `json:"-"`
+}
+
+func (m *BroadcastMeta) Conform(_ context.Context) error {
+	if b, err := hex.DecodeString(m.Transaction); err != nil || len(b) == 0 {
+		return derrors.InvalidParam
+	}
+	return nil
+}
+
+type BroadcastRL struct {
+	TxId string `json:"txId"`
+	// OrderId is the ID of the order of the unsigned transaction, it's empty
+	// if the transaction was not built by the service.
+	OrderId string `json:"orderId,omitempty"`
+	// Receipt is the final receipt of the transaction if waited.
+	Receipt *TransactionRL `json:"receipt,omitempty"`
+}
+
+var (
+	_ internal.Conformer = (*BroadcastMeta)(nil)
+)
+
+type BroadcastService interface {
+	// Broadcast broadcasts the signed transaction, the order of the unsigned
+	// transaction moves along with it.
+	Broadcast(ctx context.Context, req *BroadcastMeta) (*BroadcastRL, error)
+}
@@ -0,0 +1,86 @@
+package justlend
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/derrors"
+)
+
+// CostOption is a way of getting the energy.
+type CostOption string
+
+const (
+	// OptionRent rents the energy from JustLend.
+	OptionRent CostOption = "rent"
+	// OptionBurn burns TRX for the energy consumed by the transactions.
+	OptionBurn CostOption = "burn"
+	// OptionFreeze stakes TRX of the owner for the energy.
+	OptionFreeze CostOption = "freeze"
+)
+
+// CompareMeta compares the costs of getting the energy every day over the
+// duration, since the energy of a rental or a stake recovers in a day.
+type CompareMeta struct {
+	Energy int64
+	// Owner is the optional base58 address of the account which needs the
+	// energy, staking is only recommended if the balance of the owner is
+	// known to cover the stake.
+	Owner string
+	// Duration is the period in seconds the energy is needed, DefaultPrepay
+	// is used if it's zero.
+	Duration int64
+}
+
+func (m *CompareMeta) Conform(_ context.Context) error {
+	switch {
+	case m.Energy <= 0:
+		return derrors.InvalidParam
+	case !internal.IsEmpty(m.Owner) && !internal.IsValidAddress(m.Owner):
+		return derrors.InvalidParam
+	case m.Duration < 0 || m.Duration > maxDuration:
+		return derrors.InvalidParam
+	}
+	if m.Duration == 0 {
+		m.Duration = DefaultPrepay
+	}
+	return nil
+}
+
+type CompareRL struct {
+	Energy   int64 `json:"energy"`
+	Duration int64 `json:"duration"`
+	// Days is the number of the days the energy is consumed in full.
+	Days int64 `json:"days"`
+
+	// Rental is the quote of renting the energy, RentCost is the rent of
+	// the duration plus the fee, the deposit refunded is left out.
+	Rental   *FeeRatioRL     `json:"rental"`
+	RentCost decimal.Decimal `json:"rentCost"`
+
+	// BurnCost is the TRX burnt for the energy of every day at the
+	// EnergyPrice in SUN.
+	BurnCost    decimal.Decimal `json:"burnCost"`
+	EnergyPrice int64           `json:"energyPrice"`
+
+	// FreezeTrx is the TRX to stake for the energy, which costs nothing but
+	// is locked for the UnfreezeDelayDays after unstaking. Balance is the
+	// TRX balance of the owner if given.
+	FreezeTrx         int64            `json:"freezeTrx"`
+	UnfreezeDelayDays int64            `json:"unfreezeDelayDays"`
+	Balance           *decimal.Decimal `json:"balance,omitempty"`
+
+	Recommendation CostOption `json:"recommendation"`
+	// Reason explains the recommendation.
+	Reason string `json:"reason"`
+}
+
+var (
+	_ internal.Conformer = (*CompareMeta)(nil)
+)
+
+type CompareService interface {
+	// Compare compares the costs of renting the energy, burning TRX for it &
+	// staking TRX for it, and recommends the cheapest.
+	Compare(ctx context.Context, req *CompareMeta) (*CompareRL, error)
+}
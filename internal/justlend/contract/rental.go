@@ -0,0 +1,154 @@
+// Package contract contains the typed bindings of the JustLend contracts, the
+// bindings encode the calls & decode the results with the ABI of the contract
+// so that no call data is built by hand.
+package contract
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"justlend/internal/abi"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"math/big"
+)
+
+// rentalABIJSON is the ABI of the JustLend DAO energy rental contract.
+//
+//go:embed rental.json
+var rentalABIJSON string
+
+// RentalABI is the parsed ABI of the energy rental contract.
+var RentalABI = abi.MustJSON(rentalABIJSON)
+
+// Caller executes the read-only calls of a contract.
+type Caller interface {
+	CallView(ctx context.Context, owner, contract string, data []byte, callValue int64) (*tron.ViewResult, error)
+}
+
+// EnergyRental is the binding of the JustLend DAO energy rental contract.
+type EnergyRental struct {
+	address string
+	caller  Caller
+}
+
+// NewEnergyRental creates a binding of the rental contract deployed at the
+// given base58 address.
+func NewEnergyRental(address string, caller Caller) *EnergyRental {
+	return &EnergyRental{address: address, caller: caller}
+}
+
+// Address returns the base58 address of the contract.
+func (r *EnergyRental) Address() string { return r.address }
+
+// call executes the read-only method on behalf of the owner and decodes
+// its outputs.
+func (r *EnergyRental) call(ctx context.Context, owner, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := RentalABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.caller.CallView(ctx, owner, r.address, data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	} else if result.Reverted() {
+		return nil, fmt.Errorf("%s: %s", method, result.RevertReason)
+	} else if len(result.Results) == 0 {
+		return nil, fmt.Errorf("%s: empty result", method)
+	}
+	return RentalABI.Unpack(method, result.Results[0])
+}
+
+// callUint256 executes the read-only method which returns a single uint256.
+func (r *EnergyRental) callUint256(ctx context.Context, owner, method string, args ...interface{}) (*big.Int, error) {
+	out, err := r.call(ctx, owner, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	ints, err := bigInts(method, out, 1)
+	if err != nil {
+		return nil, err
+	}
+	return ints[0], nil
+}
+
+// bigInts asserts the decoded outputs of the method are n integers, a
+// mismatch of the ABI & the contract is derrors.InconsistentData.
+func bigInts(method string, out []interface{}, n int) ([]*big.Int, error) {
+	if len(out) != n {
+		return nil, fmt.Errorf("%s: %w: %d outputs, want %d", method, derrors.InconsistentData, len(out), n)
+	}
+	ints := make([]*big.Int, n)
+	for i, v := range out {
+		x, ok := v.(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w: output %d is %T, want *big.Int", method, derrors.InconsistentData, i, v)
+		}
+		ints[i] = x
+	}
+	return ints, nil
+}
+
+// RentalRate returns the rental rate per second of renting the resource of
+// the staked TRX amount in SUN, scaled by 1e18.
+func (r *EnergyRental) RentalRate(ctx context.Context, owner string, amount *big.Int, rt core.ResourceCode) (*big.Int, error) {
+	return r.callUint256(ctx, owner, "_rentalRate", amount, int64(rt))
+}
+
+// LiquidateThreshold returns the minimum security deposit in SUN below
+// which a rental is liquidated.
+func (r *EnergyRental) LiquidateThreshold(ctx context.Context, owner string) (*big.Int, error) {
+	return r.callUint256(ctx, owner, "liquidateThreshold")
+}
+
+// MinFee returns the minimum fee of a rental in SUN.
+func (r *EnergyRental) MinFee(ctx context.Context, owner string) (*big.Int, error) {
+	return r.callUint256(ctx, owner, "minFee")
+}
+
+// FeeRatio returns the fee ratio of the staked TRX amount, scaled by 1e18.
+func (r *EnergyRental) FeeRatio(ctx context.Context, owner string) (*big.Int, error) {
+	return r.callUint256(ctx, owner, "feeRatio")
+}
+
+// RentInfo is the state of a rental of the resource from a renter to a receiver.
+type RentInfo struct {
+	// Amount is the staked TRX amount in SUN of the rented resource.
+	Amount *big.Int
+	// SecurityDeposit is the deposit in SUN paid by the renter.
+	SecurityDeposit *big.Int
+	// AccruedFee is the rent in SUN accrued since the last settlement, which
+	// is deducted from the deposit.
+	AccruedFee *big.Int
+}
+
+// GetRentInfo returns the rental of the resource rented by the renter to the
+// receiver, the amount is zero if there's no such rental.
+func (r *EnergyRental) GetRentInfo(ctx context.Context, renter, receiver string, rt core.ResourceCode) (*RentInfo, error) {
+	out, err := r.call(ctx, renter, "getRentInfo", renter, receiver, int64(rt))
+	if err != nil {
+		return nil, err
+	}
+	ints, err := bigInts("getRentInfo", out, 3)
+	if err != nil {
+		return nil, err
+	}
+	return &RentInfo{
+		Amount:          ints[0],
+		SecurityDeposit: ints[1],
+		AccruedFee:      ints[2],
+	}, nil
+}
+
+// PackRentResource encodes the call data renting the resource of the staked
+// TRX amount in SUN to the receiver.
+func (r *EnergyRental) PackRentResource(receiver string, amount *big.Int, rt core.ResourceCode) ([]byte, error) {
+	return RentalABI.Pack("rentResource", receiver, amount, int64(rt))
+}
+
+// PackReturnResource encodes the call data returning the resource of the
+// staked TRX amount in SUN rented to the receiver.
+func (r *EnergyRental) PackReturnResource(receiver string, amount *big.Int, rt core.ResourceCode) ([]byte, error) {
+	return RentalABI.Pack("returnResource", receiver, amount, int64(rt))
+}
@@ -0,0 +1,33 @@
+package contract
+
+import (
+	"errors"
+	"justlend/internal/derrors"
+	"math/big"
+	"testing"
+)
+
+func TestBigInts(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     []interface{}
+		n       int
+		wantErr error
+	}{
+		{name: "ints", out: []interface{}{big.NewInt(1), big.NewInt(2)}, n: 2},
+		{name: "missing output", out: []interface{}{big.NewInt(1)}, n: 2, wantErr: derrors.InconsistentData},
+		{name: "no output", n: 1, wantErr: derrors.InconsistentData},
+		{name: "not an int", out: []interface{}{big.NewInt(1), true}, n: 2, wantErr: derrors.InconsistentData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bigInts("m", tt.out, tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("bigInts() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(got) != tt.n {
+				t.Errorf("bigInts() = %v, want %d ints", got, tt.n)
+			}
+		})
+	}
+}
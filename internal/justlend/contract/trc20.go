@@ -0,0 +1,15 @@
+package contract
+
+import (
+	"justlend/internal/abi"
+	"math/big"
+)
+
+// trc20Transfer is the `transfer` method of the TRC20 tokens.
+var trc20Transfer = abi.MustParseMethod("transfer(address,uint256)", "bool")
+
+// PackTransfer encodes the call data transferring the amount of the token in
+// its smallest unit to the recipient.
+func PackTransfer(to string, amount *big.Int) ([]byte, error) {
+	return trc20Transfer.Pack(to, amount)
+}
@@ -11,6 +11,11 @@
 //	@host			43.134.121.227:16688
 //	@BasePath		/v1
 
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-APIKEY
+//	@description				API密钥, 按权限范围访问接口
+
 package justlend
 
 //go:generate swag init -g doc.go -ot go -pd true -d ./
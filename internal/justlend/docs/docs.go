@@ -24,55 +24,54 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/fee": {
+        "/admin/apikeys": {
             "get": {
-                "description": "根据参数计算费用",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "查询已签发的API密钥, 包括已吊销的",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "费用计算"
+                    "管理"
                 ],
-                "summary": "计算费用.",
+                "summary": "API密钥列表.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "需要速冲的数量",
-                        "name": "energy",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "私钥",
-                        "name": "privateKey",
-                        "in": "query",
-                        "required": true
+                        "description": "偏移",
+                        "name": "offset",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "0(宽带),1(能量)",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
+                        "description": "数量(默认20, 最大100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "1000": {
                         "description": "",
                         "schema": {
-                            "$ref": "#/definitions/justlend.FeeRatioRL"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.APIKeyRL"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/rent": {
+            },
             "post": {
-                "description": "租用",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "签发API密钥, 密钥仅在签发时返回一次, 只保存其哈希",
                 "consumes": [
                     "application/json"
                 ],
@@ -80,13 +79,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "交易"
+                    "管理"
                 ],
-                "summary": "租用.",
+                "summary": "签发API密钥.",
                 "parameters": [
                     {
-                        "description": "速冲地址",
-                        "name": "receive",
+                        "description": "名称",
+                        "name": "name",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -94,30 +93,37 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "速冲类型0(宽带),1(能量)",
-                        "name": "type",
+                        "description": "权限范围(quote/rent/return/admin)",
+                        "name": "scopes",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     },
                     {
-                        "description": "速冲数量",
-                        "name": "amount",
+                        "description": "允许的扣费钱包ID或地址(为空不限制)",
+                        "name": "wallets",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     },
                     {
-                        "description": "扣费私钥",
-                        "name": "privateKey",
+                        "description": "允许的接收地址(为空不限制)",
+                        "name": "receivers",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     }
                 ],
@@ -125,15 +131,69 @@ const docTemplate = `{
                     "1000": {
                         "description": "",
                         "schema": {
-                            "$ref": "#/definitions/justlend.RentResourceRL"
+                            "$ref": "#/definitions/justlend.APIKeyRL"
                         }
                     }
                 }
             }
         },
-        "/return": {
+        "/admin/apikeys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "吊销API密钥, 之后使用该密钥的请求认证失败",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "吊销API密钥.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "密钥ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/admin/nodes": {
+            "get": {
+                "description": "查询Tron节点的健康状态",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理"
+                ],
+                "summary": "节点状态.",
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.NodeRL"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/broadcast": {
             "post": {
-                "description": "退款",
+                "description": "广播离线签名交易, 未签名交易对应的订单随之更新",
                 "consumes": [
                     "application/json"
                 ],
@@ -143,11 +203,11 @@ const docTemplate = `{
                 "tags": [
                     "交易"
                 ],
-                "summary": "退款.",
+                "summary": "广播离线签名交易.",
                 "parameters": [
                     {
-                        "description": "速冲地址",
-                        "name": "receive",
+                        "description": "已签名交易(hex)",
+                        "name": "transaction",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -155,95 +215,2070 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "速冲类型0(宽带),1(能量)",
-                        "name": "type",
-                        "in": "body",
-                        "required": true,
+                        "type": "boolean",
+                        "description": "等待交易确认",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/justlend.BroadcastRL"
                         }
+                    }
+                }
+            }
+        },
+        "/fee": {
+            "get": {
+                "description": "根据参数计算费用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "费用计算"
+                ],
+                "summary": "计算费用.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "需要速冲的数量",
+                        "name": "energy",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "description": "退款数量",
-                        "name": "stakePerTrx",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "string",
+                        "description": "调用地址(可选)",
+                        "name": "owner",
+                        "in": "query"
                     },
                     {
-                        "description": "扣费私钥",
-                        "name": "privateKey",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "integer",
+                        "description": "0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "预付租金时长(秒), 默认172800",
+                        "name": "prepay",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "合约参数读取失败时使用最近的值估算",
+                        "name": "partial",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "1000": {
                         "description": "",
                         "schema": {
-                            "$ref": "#/definitions/justlend.ReturnResourceRL"
+                            "$ref": "#/definitions/justlend.FeeRatioRL"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "justlend.FeeRatioRL": {
-            "type": "object",
-            "properties": {
-                "curFeeRatio": {
-                    "type": "number"
-                },
-                "feeRatio": {
-                    "type": "number"
-                },
-                "liquidateThreshold": {
-                    "type": "number"
-                },
-                "minFee": {
-                    "type": "number"
-                },
-                "prePayFee": {
-                    "type": "number"
-                },
-                "rentAmount": {
-                    "type": "integer"
-                },
-                "rentFee": {
-                    "type": "number"
-                },
-                "rentalRate": {
-                    "type": "number"
-                },
-                "stakePerTrx": {
-                    "type": "integer"
+        },
+        "/fee/compare": {
+            "get": {
+                "description": "对比租用、燃烧TRX与质押TRX获取能量的费用, 并给出建议",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "费用计算"
+                ],
+                "summary": "费用对比.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "每天需要的能量",
+                        "name": "energy",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "使用能量的地址(可选), 用于判断余额是否足够质押",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "需要能量的时长(秒), 默认172800",
+                        "name": "duration",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.CompareRL"
+                        }
+                    }
                 }
             }
         },
-        "justlend.RentResourceRL": {
-            "type": "object",
-            "properties": {
-                "stakePerTrx": {
-                    "type": "integer"
-                },
-                "txId": {
-                    "type": "string"
+        "/fee/schedule": {
+            "get": {
+                "description": "预测不同租用时长的总费用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "费用计算"
+                ],
+                "summary": "租用费用预测.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "需要速冲的数量",
+                        "name": "energy",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "调用地址(可选)",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "租用时长(秒), 逗号分隔",
+                        "name": "durations",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.FeeScheduleRL"
+                        }
+                    }
                 }
             }
         },
-        "justlend.ReturnResourceRL": {
-            "type": "object",
-            "properties": {
+        "/maintain": {
+            "get": {
+                "description": "查询能量维持策略",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "能量维持"
+                ],
+                "summary": "能量维持策略列表.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "偏移",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "数量(默认20, 最大100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.MaintainPolicyRL"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "维持接收地址的可用能量不低于目标值, 不足时租用, 超出时退还",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "能量维持"
+                ],
+                "summary": "创建能量维持策略.",
+                "parameters": [
+                    {
+                        "description": "接收地址",
+                        "name": "receiver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "扣费钱包ID或地址",
+                        "name": "wallet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "目标能量",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "超出目标多少后退还(默认目标的1/10)",
+                        "name": "tolerance",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "预付费用预算(SUN)",
+                        "name": "maxSpend",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "暂停",
+                        "name": "paused",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.MaintainPolicyRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintain/{id}": {
+            "get": {
+                "description": "查询能量维持策略及最近一次检查结果",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "能量维持"
+                ],
+                "summary": "能量维持策略详情.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "策略ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.MaintainPolicyRL"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "替换能量维持策略的设置, 已花费的费用保留",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "能量维持"
+                ],
+                "summary": "修改能量维持策略.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "策略ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "接收地址",
+                        "name": "receiver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "扣费钱包ID或地址",
+                        "name": "wallet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "目标能量",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "超出目标多少后退还(默认目标的1/10)",
+                        "name": "tolerance",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "预付费用预算(SUN)",
+                        "name": "maxSpend",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "暂停",
+                        "name": "paused",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.MaintainPolicyRL"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除能量维持策略, 已租用的能量保留",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "能量维持"
+                ],
+                "summary": "删除能量维持策略.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "策略ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "查询账本中的报价、租赁及退还订单, 按创建时间倒序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "订单列表.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "付款地址",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "接收地址",
+                        "name": "receiver",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "quote, rent, return",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created, broadcast, confirmed, failed, returned",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "交易ID",
+                        "name": "txId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "数量(默认20, 最大100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.OrderRL"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "查询账本中的订单",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "订单详情.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.OrderRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/events": {
+            "get": {
+                "description": "按时间顺序查询订单的状态变更记录",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "订单状态变更.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订单ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.OrderEventRL"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rent": {
+            "post": {
+                "description": "租用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "交易"
+                ],
+                "summary": "租用.",
+                "parameters": [
+                    {
+                        "description": "速冲地址",
+                        "name": "receive",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "速冲类型0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "速冲数量",
+                        "name": "amount",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "扣费钱包ID或地址",
+                        "name": "wallet",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "扣费私钥(未启用加密密钥时)",
+                        "name": "privateKey",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "租赁时长(秒), 到期自动退还, 仅支持钱包",
+                        "name": "duration",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "预付租金时长(秒), 默认172800",
+                        "name": "prepay",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "等待交易确认",
+                        "name": "wait",
+                        "in": "query"
+                    },
+                    {
+                        "description": "模拟的付款地址(仅dryRun, 无需钱包或私钥)",
+                        "name": "owner",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "仅模拟并返回未签名交易, 不广播",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.RentResourceRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/rent/unsigned": {
+            "post": {
+                "description": "构建未签名租用交易, 离线签名后通过 /broadcast 广播",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "交易"
+                ],
+                "summary": "构建未签名租用交易.",
+                "parameters": [
+                    {
+                        "description": "扣费地址",
+                        "name": "owner",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "速冲地址",
+                        "name": "receive",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "速冲类型0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "速冲数量",
+                        "name": "amount",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "预付租金时长(秒), 默认172800",
+                        "name": "prepay",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.RentResourceRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/rentals": {
+            "get": {
+                "description": "查询租赁地址给接收地址的租赁及保证金状态",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租赁"
+                ],
+                "summary": "租赁查询.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租赁地址",
+                        "name": "renter",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "接收地址",
+                        "name": "receiver",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0(宽带),1(能量), 不传则查询全部",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.RentalRL"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/return": {
+            "post": {
+                "description": "退款",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "交易"
+                ],
+                "summary": "退款.",
+                "parameters": [
+                    {
+                        "description": "速冲地址",
+                        "name": "receive",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "速冲类型0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "退款数量(SUN), 不传则退还全部",
+                        "name": "stakePerTrx",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "扣费钱包ID或地址",
+                        "name": "wallet",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "扣费私钥(未启用加密密钥时)",
+                        "name": "privateKey",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "等待交易确认",
+                        "name": "wait",
+                        "in": "query"
+                    },
+                    {
+                        "description": "模拟的付款地址(仅dryRun, 无需钱包或私钥)",
+                        "name": "owner",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "仅模拟并返回未签名交易, 不广播",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.ReturnResourceRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/return/unsigned": {
+            "post": {
+                "description": "构建未签名退款交易, 离线签名后通过 /broadcast 广播",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "交易"
+                ],
+                "summary": "构建未签名退款交易.",
+                "parameters": [
+                    {
+                        "description": "扣费地址",
+                        "name": "owner",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "速冲地址",
+                        "name": "receive",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "速冲类型0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "退款数量(SUN), 不传则退还全部",
+                        "name": "stakePerTrx",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.ReturnResourceRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules": {
+            "get": {
+                "description": "查询定时自动退还的租赁, 按退还时间排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "自动退还列表.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending, done, failed, cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "数量(默认20, 最大100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.ScheduleRL"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{orderId}": {
+            "delete": {
+                "description": "取消租赁订单的定时自动退还, 租赁将保留",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "取消自动退还.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租赁订单ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.ScheduleRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfer": {
+            "post": {
+                "description": "TRC20转账, 先租用不足的能量, 转账确认后可退还",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "交易"
+                ],
+                "summary": "TRC20转账.",
+                "parameters": [
+                    {
+                        "description": "TRC20合约地址",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "收款地址",
+                        "name": "to",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "转账数量(最小单位)",
+                        "name": "amount",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "转账钱包ID或地址",
+                        "name": "wallet",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "转账私钥(未启用加密密钥时)",
+                        "name": "privateKey",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "转账后退还租用的能量",
+                        "name": "return",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.TransferRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/tx/{id}": {
+            "get": {
+                "description": "查询交易的确认状态及收据",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "交易"
+                ],
+                "summary": "交易状态.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "交易ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.TransactionRL"
+                        }
+                    }
+                }
+            }
+        },
+        "/watches": {
+            "get": {
+                "description": "查询租赁的保证金监控策略",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租赁"
+                ],
+                "summary": "清算监控列表.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "偏移",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "数量(默认20, 最大100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/justlend.WatchPolicyRL"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "设置租赁的保证金监控策略, 保证金低于清算阈值加余量时告警或自动补充",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租赁"
+                ],
+                "summary": "设置清算监控.",
+                "parameters": [
+                    {
+                        "description": "租赁地址",
+                        "name": "renter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "接收地址",
+                        "name": "receiver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "alert(告警), topup(自动补充), none(不监控)",
+                        "name": "action",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "清算阈值之上的余量(SUN)",
+                        "name": "margin",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "补充保证金的钱包ID或地址(topup时必填)",
+                        "name": "wallet",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "每次补充的保证金(SUN, topup时必填)",
+                        "name": "topUp",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "告警地址, 不传则使用默认地址",
+                        "name": "webhook",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/justlend.WatchPolicyRL"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除租赁的保证金监控策略, 恢复默认策略",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租赁"
+                ],
+                "summary": "删除清算监控.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "租赁地址",
+                        "name": "renter",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "接收地址",
+                        "name": "receiver",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0(宽带),1(能量)",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "1000": {
+                        "description": ""
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "core.ResourceCode": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "ResourceCode_BANDWIDTH",
+                "ResourceCode_ENERGY",
+                "ResourceCode_TRON_POWER"
+            ]
+        },
+        "justlend.APIKeyRL": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Key is the raw key, which is only returned once when it's issued,\nthe ledger keeps its hash.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "receivers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/justlend.APIKeyScope"
+                    }
+                },
+                "wallets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "justlend.APIKeyScope": {
+            "type": "string",
+            "enum": [
+                "quote",
+                "rent",
+                "return",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "ScopeQuote",
+                "ScopeRent",
+                "ScopeReturn",
+                "ScopeAdmin"
+            ]
+        },
+        "justlend.BroadcastRL": {
+            "type": "object",
+            "properties": {
+                "orderId": {
+                    "description": "OrderId is the ID of the order of the unsigned transaction, it's empty\nif the transaction was not built by the service.",
+                    "type": "string"
+                },
+                "receipt": {
+                    "description": "Receipt is the final receipt of the transaction if waited.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.TransactionRL"
+                        }
+                    ]
+                },
+                "txId": {
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.CompareRL": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "burnCost": {
+                    "description": "BurnCost is the TRX burnt for the energy of every day at the\nEnergyPrice in SUN.",
+                    "type": "number"
+                },
+                "days": {
+                    "description": "Days is the number of the days the energy is consumed in full.",
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "energy": {
+                    "type": "integer"
+                },
+                "energyPrice": {
+                    "type": "integer"
+                },
+                "freezeTrx": {
+                    "description": "FreezeTrx is the TRX to stake for the energy, which costs nothing but\nis locked for the UnfreezeDelayDays after unstaking. Balance is the\nTRX balance of the owner if given.",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Reason explains the recommendation.",
+                    "type": "string"
+                },
+                "recommendation": {
+                    "$ref": "#/definitions/justlend.CostOption"
+                },
+                "rentCost": {
+                    "type": "number"
+                },
+                "rental": {
+                    "description": "Rental is the quote of renting the energy, RentCost is the rent of\nthe duration plus the fee, the deposit refunded is left out.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.FeeRatioRL"
+                        }
+                    ]
+                },
+                "unfreezeDelayDays": {
+                    "type": "integer"
+                }
+            }
+        },
+        "justlend.CostOption": {
+            "type": "string",
+            "enum": [
+                "rent",
+                "burn",
+                "freeze"
+            ],
+            "x-enum-varnames": [
+                "OptionRent",
+                "OptionBurn",
+                "OptionFreeze"
+            ]
+        },
+        "justlend.DryRunRL": {
+            "type": "object",
+            "properties": {
+                "callValue": {
+                    "type": "integer"
+                },
+                "contractResult": {
+                    "description": "ContractResult is SUCCESS unless the call would fail, i.e. REVERT.",
+                    "type": "string"
+                },
+                "energyPenalty": {
+                    "type": "integer"
+                },
+                "energyUsed": {
+                    "type": "integer"
+                },
+                "expiration": {
+                    "type": "string"
+                },
+                "feeLimit": {
+                    "description": "FeeLimit \u0026 CallValue are in SUN.",
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "rawData": {
+                    "type": "string"
+                },
+                "rawDataJson": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "revertReason": {
+                    "type": "string"
+                },
+                "txId": {
+                    "description": "TxId \u0026 RawData are the txID \u0026 the hex encoded raw data of the unsigned\ntransaction, which must be signed before the Expiration. RawDataJSON\nis the raw data decoded for review.",
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.FeeRatioRL": {
+            "type": "object",
+            "properties": {
+                "curFeeRatio": {
+                    "type": "number"
+                },
+                "dailyRent": {
+                    "type": "number"
+                },
+                "estimated": {
+                    "description": "Estimated lists the parameters of a partial quote which were not read\nfrom the contract, i.e. liquidateThreshold, rentalRate, feeRatio \u0026\nminFee, the quote is exact if it's empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "feeRatio": {
+                    "type": "number"
+                },
+                "hourlyRent": {
+                    "type": "number"
+                },
+                "liquidateThreshold": {
+                    "type": "number"
+                },
+                "minFee": {
+                    "type": "number"
+                },
+                "orderId": {
+                    "description": "OrderId is the ID of the quote recorded in the ledger, it's empty if\nthe quote couldn't be recorded.",
+                    "type": "string"
+                },
+                "prePayFee": {
+                    "description": "PrePayFee is the call value of the rental in TRX, as a JSON number.",
+                    "type": "number"
+                },
+                "prepay": {
+                    "description": "Prepay is the prepaid period in seconds of the RentFee, HourlyRent \u0026\nDailyRent are the rent of an hour \u0026 a day.",
+                    "type": "integer"
+                },
+                "rentAmount": {
+                    "type": "integer"
+                },
+                "rentFee": {
+                    "type": "number"
+                },
+                "rentalRate": {
+                    "type": "number"
+                },
+                "stakePerTrx": {
+                    "type": "integer"
+                }
+            }
+        },
+        "justlend.FeeScheduleItem": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "rent": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "justlend.FeeScheduleRL": {
+            "type": "object",
+            "properties": {
+                "feeRatio": {
+                    "description": "FeeRatio is the one-off fee of the rental.",
+                    "type": "number"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/justlend.FeeScheduleItem"
+                    }
+                },
+                "liquidateThreshold": {
+                    "description": "LiquidateThreshold is the deposit refunded by the return, which is\nnot a cost of the rental.",
+                    "type": "number"
+                },
+                "rentAmount": {
+                    "type": "integer"
+                },
+                "rentalRate": {
+                    "type": "number"
+                },
+                "stakePerTrx": {
+                    "type": "integer"
+                }
+            }
+        },
+        "justlend.MaintainPolicyRL": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Available is the available energy of the receiver at CheckedAt.",
+                    "type": "integer"
+                },
+                "checkedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastOrderId": {
+                    "description": "LastOrderId is the last rent or return order of the policy.",
+                    "type": "string"
+                },
+                "maxSpend": {
+                    "type": "integer"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "receiver": {
+                    "type": "string"
+                },
+                "spent": {
+                    "description": "Spent is the total prepaid fees in SUN of the rentals.",
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "integer"
+                },
+                "tolerance": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.NodeRL": {
+            "type": "object",
+            "properties": {
+                "addr": {
+                    "type": "string"
+                },
+                "blockHeight": {
+                    "type": "integer"
+                },
+                "checkedAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "lag": {
+                    "type": "integer"
+                },
+                "latency": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.OrderEventRL": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/justlend.OrderStatus"
+                },
+                "txId": {
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.OrderKind": {
+            "type": "string",
+            "enum": [
+                "quote",
+                "rent",
+                "return",
+                "topup"
+            ],
+            "x-enum-varnames": [
+                "OrderQuote",
+                "OrderRent",
+                "OrderReturn",
+                "OrderTopUp"
+            ]
+        },
+        "justlend.OrderRL": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is the amount of the rented resource.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error is the reason of the failed orders.",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/justlend.OrderKind"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "receiver": {
+                    "type": "string"
+                },
+                "stakePerTrx": {
+                    "description": "StakePerTrx \u0026 Fee are the staked TRX \u0026 the prepaid fee in SUN.",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/justlend.OrderStatus"
+                },
+                "txId": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/core.ResourceCode"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "broadcast",
+                "confirmed",
+                "failed",
+                "returned"
+            ],
+            "x-enum-varnames": [
+                "OrderCreated",
+                "OrderBroadcast",
+                "OrderConfirmed",
+                "OrderFailed",
+                "OrderReturned"
+            ]
+        },
+        "justlend.RentResourceRL": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "DryRun is the simulation of a dry run, no order is recorded then.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.DryRunRL"
+                        }
+                    ]
+                },
+                "orderId": {
+                    "description": "OrderId is the ID of the order recorded in the ledger.",
+                    "type": "string"
+                },
+                "receipt": {
+                    "description": "Receipt is the final receipt of the transaction if waited.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.TransactionRL"
+                        }
+                    ]
+                },
+                "returnAt": {
+                    "description": "ReturnAt is the time the rental is returned if a duration is given.",
+                    "type": "string"
+                },
+                "stakePerTrx": {
+                    "type": "integer"
+                },
+                "txId": {
+                    "type": "string"
+                },
+                "unsigned": {
+                    "description": "Unsigned is the transaction built to be signed offline, which is sent\nto the broadcast once signed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.DryRunRL"
+                        }
+                    ]
+                }
+            }
+        },
+        "justlend.RentalRL": {
+            "type": "object",
+            "properties": {
+                "accruedFee": {
+                    "type": "number"
+                },
+                "liquidatable": {
+                    "description": "Liquidatable reports whether the remaining deposit falls below the\nliquidation threshold.",
+                    "type": "boolean"
+                },
+                "liquidateThreshold": {
+                    "type": "number"
+                },
+                "receiver": {
+                    "type": "string"
+                },
+                "remainingDeposit": {
+                    "type": "number"
+                },
+                "renter": {
+                    "type": "string"
+                },
+                "securityDeposit": {
+                    "type": "number"
+                },
+                "stakePerTrx": {
+                    "description": "StakePerTrx is the staked TRX amount in SUN of the rented resource,\nwhich is the amount to return.",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/core.ResourceCode"
+                }
+            }
+        },
+        "justlend.ReturnResourceRL": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "DryRun is the simulation of a dry run, no order is recorded then.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.DryRunRL"
+                        }
+                    ]
+                },
+                "orderId": {
+                    "description": "OrderId is the ID of the order recorded in the ledger.",
+                    "type": "string"
+                },
+                "receipt": {
+                    "description": "Receipt is the final receipt of the transaction if waited.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.TransactionRL"
+                        }
+                    ]
+                },
+                "stakePerTrx": {
+                    "type": "integer"
+                },
+                "txId": {
+                    "type": "string"
+                },
+                "unsigned": {
+                    "description": "Unsigned is the transaction built to be signed offline, which is sent\nto the broadcast once signed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.DryRunRL"
+                        }
+                    ]
+                }
+            }
+        },
+        "justlend.ScheduleRL": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts counts the failed attempts, the next attempt is made at\nNextAttemptAt.",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "description": "OrderId is the ID of the rent order to return.",
+                    "type": "string"
+                },
+                "receiver": {
+                    "type": "string"
+                },
+                "returnAt": {
+                    "type": "string"
+                },
+                "returnOrderId": {
+                    "description": "ReturnOrderId is the ID of the last return order.",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/justlend.ScheduleStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/core.ResourceCode"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.ScheduleStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "done",
+                "failed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "SchedulePending",
+                "ScheduleDone",
+                "ScheduleFailed",
+                "ScheduleCancelled"
+            ]
+        },
+        "justlend.TransactionRL": {
+            "type": "object",
+            "properties": {
+                "blockNumber": {
+                    "type": "integer"
+                },
+                "blockTimestamp": {
+                    "type": "integer"
+                },
+                "contractOutput": {
+                    "type": "string"
+                },
+                "contractResult": {
+                    "type": "string"
+                },
+                "energyFee": {
+                    "type": "integer"
+                },
+                "energyUsed": {
+                    "type": "integer"
+                },
+                "fee": {
+                    "description": "Total fee burnt in SUN.",
+                    "type": "integer"
+                },
+                "netFee": {
+                    "type": "integer"
+                },
+                "netUsed": {
+                    "type": "integer"
+                },
+                "revertReason": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "One of pending, confirmed, reverted \u0026 expired.",
+                    "type": "string"
+                },
+                "txId": {
+                    "type": "string"
+                }
+            }
+        },
+        "justlend.TransferRL": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "burnCost": {
+                    "type": "integer"
+                },
+                "energy": {
+                    "description": "Energy is the estimated energy of the transfer \u0026 Available is the\nenergy the sender had before it, the shortfall is Rented.",
+                    "type": "integer"
+                },
+                "energyPrice": {
+                    "type": "integer"
+                },
+                "fees": {
+                    "type": "integer"
+                },
+                "receipt": {
+                    "description": "Receipt is the final receipt of the transfer.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/justlend.TransactionRL"
+                        }
+                    ]
+                },
+                "refund": {
+                    "type": "integer"
+                },
+                "rentFee": {
+                    "description": "The costs in SUN. RentFee is the prepaid fee of the rental, Refund is\nthe estimated deposit refunded by the return, Fees is the TRX burnt\nby all the transactions.",
+                    "type": "integer"
+                },
+                "rentOrderId": {
+                    "description": "The orders \u0026 the transactions of the rent \u0026 the return if any.",
+                    "type": "string"
+                },
+                "rentTxId": {
+                    "type": "string"
+                },
+                "rented": {
+                    "type": "integer"
+                },
+                "returnError": {
+                    "description": "ReturnError is the reason the rented energy was not returned, the\ntransfer is already done then.",
+                    "type": "string"
+                },
+                "returnOrderId": {
+                    "type": "string"
+                },
+                "returnTxId": {
+                    "type": "string"
+                },
+                "totalCost": {
+                    "description": "TotalCost is RentFee - Refund + Fees, BurnCost is what the transfer\ncosts if the shortfall is burnt at the EnergyPrice instead.",
+                    "type": "integer"
+                },
                 "txId": {
                     "type": "string"
                 }
             }
+        },
+        "justlend.WatchAction": {
+            "type": "string",
+            "enum": [
+                "alert",
+                "topup",
+                "none"
+            ],
+            "x-enum-varnames": [
+                "WatchAlert",
+                "WatchTopUp",
+                "WatchNone"
+            ]
+        },
+        "justlend.WatchPolicyRL": {
+            "type": "object",
+            "properties": {
+                "actedAt": {
+                    "description": "ActedAt is the time of the last alert or top-up.",
+                    "type": "string"
+                },
+                "action": {
+                    "$ref": "#/definitions/justlend.WatchAction"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "margin": {
+                    "type": "integer"
+                },
+                "receiver": {
+                    "type": "string"
+                },
+                "renter": {
+                    "type": "string"
+                },
+                "topUp": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/core.ResourceCode"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                },
+                "webhook": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API密钥, 按权限范围访问接口",
+            "type": "apiKey",
+            "name": "X-APIKEY",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,40 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type IssueAPIKeyRequest struct {
+	*justlend.APIKeyMeta
+}
+
+func MakeIssueAPIKeyEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*IssueAPIKeyRequest)
+		return NewResponse(s.IssueAPIKey(ctx, req.APIKeyMeta)), nil
+	})
+}
+
+type APIKeysRequest struct {
+	*justlend.APIKeysMeta
+}
+
+func MakeAPIKeysEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*APIKeysRequest)
+		return NewListResponse(s.APIKeys(ctx, req.APIKeysMeta)), nil
+	})
+}
+
+type RevokeAPIKeyRequest struct {
+	*justlend.APIKeyIdMeta
+}
+
+func MakeRevokeAPIKeyEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*RevokeAPIKeyRequest)
+		return NewErrResponse(s.RevokeAPIKey(ctx, req.APIKeyIdMeta)), nil
+	})
+}
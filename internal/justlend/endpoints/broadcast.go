@@ -0,0 +1,18 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type BroadcastRequest struct {
+	*justlend.BroadcastMeta
+}
+
+func MakeBroadcastEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*BroadcastRequest)
+		return NewResponse(s.Broadcast(ctx, req.BroadcastMeta)), nil
+	})
+}
@@ -0,0 +1,18 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type CompareRequest struct {
+	*justlend.CompareMeta
+}
+
+func MakeCompareEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CompareRequest)
+		return NewResponse(s.Compare(ctx, req.CompareMeta)), nil
+	})
+}
@@ -16,3 +16,14 @@ func MakeFeeRatioEndpoint(s justlend.Service) endpoint.Endpoint {
 		return NewResponse(s.FeeRatio(ctx, req.FeeRatioMeta)), nil
 	})
 }
+
+type FeeScheduleRequest struct {
+	*justlend.FeeScheduleMeta
+}
+
+func MakeFeeScheduleEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*FeeScheduleRequest)
+		return NewResponse(s.FeeSchedule(ctx, req.FeeScheduleMeta)), nil
+	})
+}
@@ -0,0 +1,58 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type CreateMaintainRequest struct {
+	*justlend.MaintainPolicyMeta
+}
+
+func MakeCreateMaintainEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*CreateMaintainRequest)
+		return NewResponse(s.CreateMaintain(ctx, req.MaintainPolicyMeta)), nil
+	})
+}
+
+type MaintainsRequest struct {
+	*justlend.MaintainsMeta
+}
+
+func MakeMaintainsEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*MaintainsRequest)
+		return NewListResponse(s.Maintains(ctx, req.MaintainsMeta)), nil
+	})
+}
+
+type MaintainRequest struct {
+	*justlend.MaintainMeta
+}
+
+func MakeGetMaintainEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*MaintainRequest)
+		return NewResponse(s.GetMaintain(ctx, req.MaintainMeta)), nil
+	})
+}
+
+func MakeDeleteMaintainEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*MaintainRequest)
+		return NewErrResponse(s.DeleteMaintain(ctx, req.MaintainMeta)), nil
+	})
+}
+
+type UpdateMaintainRequest struct {
+	*justlend.UpdateMaintainMeta
+}
+
+func MakeUpdateMaintainEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*UpdateMaintainRequest)
+		return NewResponse(s.UpdateMaintain(ctx, req.UpdateMaintainMeta)), nil
+	})
+}
@@ -0,0 +1,13 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+func MakeNodesEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, _ interface{}) (response interface{}, err error) {
+		return NewResponse(s.Nodes(ctx)), nil
+	})
+}
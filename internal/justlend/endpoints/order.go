@@ -0,0 +1,36 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type OrdersRequest struct {
+	*justlend.OrdersMeta
+}
+
+func MakeOrdersEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*OrdersRequest)
+		return NewListResponse(s.Orders(ctx, req.OrdersMeta)), nil
+	})
+}
+
+type OrderRequest struct {
+	*justlend.OrderMeta
+}
+
+func MakeOrderEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*OrderRequest)
+		return NewResponse(s.Order(ctx, req.OrderMeta)), nil
+	})
+}
+
+func MakeOrderEventsEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*OrderRequest)
+		return NewListResponse(s.OrderEvents(ctx, req.OrderMeta)), nil
+	})
+}
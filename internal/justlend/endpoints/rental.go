@@ -0,0 +1,18 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type RentalRequest struct {
+	*justlend.RentalMeta
+}
+
+func MakeRentalsEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*RentalRequest)
+		return NewResponse(s.Rentals(ctx, req.RentalMeta)), nil
+	})
+}
@@ -0,0 +1,29 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type SchedulesRequest struct {
+	*justlend.SchedulesMeta
+}
+
+func MakeSchedulesEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*SchedulesRequest)
+		return NewListResponse(s.Schedules(ctx, req.SchedulesMeta)), nil
+	})
+}
+
+type ScheduleRequest struct {
+	*justlend.ScheduleMeta
+}
+
+func MakeCancelScheduleEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*ScheduleRequest)
+		return NewResponse(s.CancelSchedule(ctx, req.ScheduleMeta)), nil
+	})
+}
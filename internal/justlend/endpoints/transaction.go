@@ -0,0 +1,18 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type TransactionRequest struct {
+	*justlend.TransactionMeta
+}
+
+func MakeTransactionEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*TransactionRequest)
+		return NewResponse(s.Transaction(ctx, req.TransactionMeta)), nil
+	})
+}
@@ -0,0 +1,18 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type TransferRequest struct {
+	*justlend.TransferMeta
+}
+
+func MakeTransferEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*TransferRequest)
+		return NewResponse(s.Transfer(ctx, req.TransferMeta)), nil
+	})
+}
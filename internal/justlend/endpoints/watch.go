@@ -0,0 +1,40 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"justlend/internal/justlend"
+)
+
+type SetWatchRequest struct {
+	*justlend.WatchPolicyMeta
+}
+
+func MakeSetWatchEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*SetWatchRequest)
+		return NewResponse(s.SetWatch(ctx, req.WatchPolicyMeta)), nil
+	})
+}
+
+type WatchesRequest struct {
+	*justlend.WatchesMeta
+}
+
+func MakeWatchesEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*WatchesRequest)
+		return NewListResponse(s.Watches(ctx, req.WatchesMeta)), nil
+	})
+}
+
+type DeleteWatchRequest struct {
+	*justlend.WatchMeta
+}
+
+func MakeDeleteWatchEndpoint(s justlend.Service) endpoint.Endpoint {
+	return Sentry(func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*DeleteWatchRequest)
+		return NewErrResponse(s.DeleteWatch(ctx, req.WatchMeta)), nil
+	})
+}
@@ -9,16 +9,46 @@ import (
 )
 
 type FeeRatioMeta struct {
-	Energy     int64
-	PrivateKey string
-	Type       core.ResourceCode
+	// Energy is the amount of the resource to rent, which is the bandwidth
+	// if the Type is BANDWIDTH.
+	Energy int64
+	// Owner is the optional base58 address used as the caller of the read-only
+	// contract calls, a neutral caller is used if it's left empty.
+	Owner string
+	Type  core.ResourceCode
+	// Prepay is the period in seconds of the rent prepaid by the rental,
+	// DefaultPrepay is used if it's zero.
+	Prepay int64
+	// Partial quotes the fee even if some of the contract parameters can't
+	// be read, their last known values are used & listed in the Estimated
+	// of the quote. The quote fails otherwise.
+	Partial bool
+}
+
+const (
+	// DefaultPrepay is two days of prepaid rent.
+	DefaultPrepay = 2 * 24 * 3600
+	// The bounds of the prepay period, the rent of a shorter period is
+	// easily overrun by the accrued fee & the rental liquidated.
+	MinPrepay = 3600
+	MaxPrepay = 30 * 24 * 3600
+)
+
+// conformPrepay defaults the zero prepay period & checks the bounds.
+func conformPrepay(prepay *int64) error {
+	if *prepay == 0 {
+		*prepay = DefaultPrepay
+	} else if *prepay < MinPrepay || *prepay > MaxPrepay {
+		return derrors.InvalidParam
+	}
+	return nil
 }
 
 func (m *FeeRatioMeta) Conform(_ context.Context) error {
 	switch {
 	case m.Energy <= 0:
 		return derrors.InvalidParam
-	case len(m.PrivateKey) != 64:
+	case !internal.IsEmpty(m.Owner) && !internal.IsValidAddress(m.Owner):
 		return derrors.InvalidParam
 	case !internal.Contains(m.Type,
 		core.ResourceCode_BANDWIDTH,
@@ -26,11 +56,14 @@ func (m *FeeRatioMeta) Conform(_ context.Context) error {
 	):
 		return derrors.InvalidParam
 	default:
-		return nil
+		return conformPrepay(&m.Prepay)
 	}
 }
 
 type FeeRatioRL struct {
+	// OrderId is the ID of the quote recorded in the ledger, it's empty if
+	// the quote couldn't be recorded.
+	OrderId            string          `json:"orderId"`
 	RentAmount         int64           `json:"rentAmount"`
 	StakePerTrx        int64           `json:"stakePerTrx"`
 	LiquidateThreshold decimal.Decimal `json:"liquidateThreshold"`
@@ -39,14 +72,93 @@ type FeeRatioRL struct {
 	MinFee             decimal.Decimal `json:"minFee"`
 	CurFeeRatio        decimal.Decimal `json:"curFeeRatio"`
 	RentFee            decimal.Decimal `json:"rentFee"`
-	PrePayFee          float64         `json:"prePayFee"`
+	// PrePayFee is the call value of the rental in TRX, as a JSON number.
+	PrePayFee DecimalNumber `json:"prePayFee" swaggertype:"number"`
+	// Prepay is the prepaid period in seconds of the RentFee, HourlyRent &
+	// DailyRent are the rent of an hour & a day.
+	Prepay     int64           `json:"prepay"`
+	HourlyRent decimal.Decimal `json:"hourlyRent"`
+	DailyRent  decimal.Decimal `json:"dailyRent"`
+	// Estimated lists the parameters of a partial quote which were not read
+	// from the contract, i.e. liquidateThreshold, rentalRate, feeRatio &
+	// minFee, the quote is exact if it's empty.
+	Estimated []string `json:"estimated,omitempty"`
+}
+
+// DecimalNumber is a decimal encoded as a JSON number rather than the string
+// of decimal.Decimal, it decodes both.
+type DecimalNumber struct {
+	decimal.Decimal
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d DecimalNumber) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// FeeScheduleMeta projects the cost of renting the resource over the durations.
+type FeeScheduleMeta struct {
+	Energy int64
+	Owner  string
+	Type   core.ResourceCode
+	// Durations are the rental durations in seconds, DefaultDurations are
+	// projected if it's empty.
+	Durations []int64
+}
+
+// DefaultDurations are an hour, a day, two days, a week & thirty days.
+var DefaultDurations = []int64{3600, 24 * 3600, 2 * 24 * 3600, 7 * 24 * 3600, 30 * 24 * 3600}
+
+const (
+	maxDurations = 20
+	// maxDuration is a year.
+	maxDuration = 365 * 24 * 3600
+)
+
+func (m *FeeScheduleMeta) Conform(ctx context.Context) error {
+	if err := (&FeeRatioMeta{Energy: m.Energy, Owner: m.Owner, Type: m.Type}).Conform(ctx); err != nil {
+		return err
+	} else if len(m.Durations) > maxDurations {
+		return derrors.InvalidParam
+	} else if len(m.Durations) == 0 {
+		m.Durations = DefaultDurations
+	}
+	for _, d := range m.Durations {
+		if d <= 0 || d > maxDuration {
+			return derrors.InvalidParam
+		}
+	}
+	return nil
+}
+
+type FeeScheduleRL struct {
+	RentAmount  int64           `json:"rentAmount"`
+	StakePerTrx int64           `json:"stakePerTrx"`
+	RentalRate  decimal.Decimal `json:"rentalRate"`
+	// FeeRatio is the one-off fee of the rental.
+	FeeRatio decimal.Decimal `json:"feeRatio"`
+	// LiquidateThreshold is the deposit refunded by the return, which is
+	// not a cost of the rental.
+	LiquidateThreshold decimal.Decimal    `json:"liquidateThreshold"`
+	Items              []*FeeScheduleItem `json:"items"`
+}
+
+// FeeScheduleItem is the total cost in TRX of renting over the duration,
+// which is the rent of the duration plus the one-off fee.
+type FeeScheduleItem struct {
+	Duration int64           `json:"duration"`
+	Rent     decimal.Decimal `json:"rent"`
+	Total    decimal.Decimal `json:"total"`
 }
 
 var (
 	_ internal.Conformer = (*FeeRatioMeta)(nil)
+	_ internal.Conformer = (*FeeScheduleMeta)(nil)
 )
 
 type FeeRatioService interface {
 	// FeeRatio calculates the fee ratio based on the provided metadata
 	FeeRatio(ctx context.Context, req *FeeRatioMeta) (*FeeRatioRL, error)
+	// FeeSchedule projects the total cost of the rental over the durations.
+	FeeSchedule(ctx context.Context, req *FeeScheduleMeta) (*FeeScheduleRL, error)
 }
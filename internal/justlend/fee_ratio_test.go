@@ -0,0 +1,55 @@
+package justlend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/shopspring/decimal"
+	"justlend/internal/protos/core"
+	"testing"
+)
+
+func TestFeeRatioMetaConform(t *testing.T) {
+	tests := []struct {
+		name       string
+		meta       FeeRatioMeta
+		wantErr    bool
+		wantPrepay int64
+	}{
+		{name: "energy", meta: FeeRatioMeta{Energy: 65000, Type: core.ResourceCode_ENERGY}, wantPrepay: DefaultPrepay},
+		{name: "bandwidth", meta: FeeRatioMeta{Energy: 345, Type: core.ResourceCode_BANDWIDTH}, wantPrepay: DefaultPrepay},
+		{name: "bandwidth prepaid", meta: FeeRatioMeta{Energy: 345, Type: core.ResourceCode_BANDWIDTH, Prepay: MinPrepay},
+			wantPrepay: MinPrepay},
+		{name: "tron power", meta: FeeRatioMeta{Energy: 1, Type: core.ResourceCode_TRON_POWER}, wantErr: true},
+		{name: "no amount", meta: FeeRatioMeta{Type: core.ResourceCode_BANDWIDTH}, wantErr: true},
+		{name: "short prepay", meta: FeeRatioMeta{Energy: 1, Type: core.ResourceCode_ENERGY, Prepay: MinPrepay - 1},
+			wantErr: true},
+		{name: "long prepay", meta: FeeRatioMeta{Energy: 1, Type: core.ResourceCode_ENERGY, Prepay: MaxPrepay + 1},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.meta.Conform(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Conform() error = %v, wantErr %v", err, tt.wantErr)
+			} else if !tt.wantErr && tt.meta.Prepay != tt.wantPrepay {
+				t.Errorf("Prepay = %d, want %d", tt.meta.Prepay, tt.wantPrepay)
+			}
+		})
+	}
+}
+
+func TestDecimalNumber(t *testing.T) {
+	b, err := json.Marshal(FeeRatioRL{PrePayFee: DecimalNumber{decimal.RequireFromString("40.120348")}})
+	if err != nil {
+		t.Fatal(err)
+	} else if !bytes.Contains(b, []byte(`"prePayFee":40.120348,`)) {
+		t.Errorf("Marshal() = %s, want prePayFee as a number", b)
+	}
+	for _, s := range []string{`{"prePayFee":40.120348}`, `{"prePayFee":"40.120348"}`} {
+		var rl FeeRatioRL
+		if err = json.Unmarshal([]byte(s), &rl); err != nil || rl.PrePayFee.String() != "40.120348" {
+			t.Errorf("Unmarshal(%s) = %v, %v", s, rl.PrePayFee, err)
+		}
+	}
+}
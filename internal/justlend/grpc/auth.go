@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+)
+
+// apiKeyMetadata is the metadata key of the API key, the counterpart of the
+// X-APIKEY header of the HTTP API.
+const apiKeyMetadata = "x-apikey"
+
+// methodScopes are the scopes required by the methods, the key must have
+// any of them.
+var methodScopes = map[string][]justlend.APIKeyScope{
+	"/justlend.v1.JustLend/Quote":       {justlend.ScopeQuote},
+	"/justlend.v1.JustLend/Rent":        {justlend.ScopeRent},
+	"/justlend.v1.JustLend/Return":      {justlend.ScopeReturn},
+	"/justlend.v1.JustLend/GetRental":   {justlend.ScopeQuote},
+	"/justlend.v1.JustLend/WatchRental": {justlend.ScopeQuote},
+}
+
+// authenticate returns the context of the call carrying its API key, the
+// unknown methods are left to the admin keys.
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if !s.apiKeyEnabled {
+		return ctx, nil
+	}
+	var key string
+	if vs := metadata.ValueFromIncomingContext(ctx, apiKeyMetadata); len(vs) > 0 {
+		key = vs[0]
+	}
+	k, err := justlend.Authenticate(ctx, s.service, s.adminAPIKey, key)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	scopes, ok := methodScopes[method]
+	if !ok {
+		scopes = []justlend.APIKeyScope{justlend.ScopeAdmin}
+	}
+	if !k.Allows(scopes...) {
+		return nil, toStatus(derrors.Forbidden)
+	}
+	return justlend.WithAPIKey(ctx, k), nil
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream is a server stream whose context carries its API key.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	justlendv1 "justlend/internal/protos/justlend/v1"
+)
+
+// Quote implements justlendv1.JustLendServer.
+func (s *Server) Quote(ctx context.Context, req *justlendv1.QuoteRequest) (*justlendv1.Quote, error) {
+	_, rep, err := s.quote.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rep.(*justlendv1.Quote), nil
+}
+
+func decodeQuoteRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(*justlendv1.QuoteRequest)
+	return &endpoints.FeeRatioRequest{FeeRatioMeta: &justlend.FeeRatioMeta{
+		Energy:  req.GetAmount(),
+		Owner:   req.GetOwner(),
+		Type:    resourceCode(req.GetType()),
+		Prepay:  req.GetPrepay(),
+		Partial: req.GetPartial(),
+	}}, nil
+}
+
+func encodeQuoteResponse(_ context.Context, response interface{}) (interface{}, error) {
+	if err := failed(response); err != nil {
+		return nil, err
+	}
+	rl := response.(endpoints.Response).Result.(*justlend.FeeRatioRL)
+	return &justlendv1.Quote{
+		OrderId:            rl.OrderId,
+		RentAmount:         rl.RentAmount,
+		StakePerTrx:        rl.StakePerTrx,
+		LiquidateThreshold: rl.LiquidateThreshold.String(),
+		RentalRate:         rl.RentalRate.String(),
+		FeeRatio:           rl.FeeRatio.String(),
+		MinFee:             rl.MinFee.String(),
+		CurFeeRatio:        rl.CurFeeRatio.String(),
+		RentFee:            rl.RentFee.String(),
+		PrePayFee:          rl.PrePayFee.String(),
+		Prepay:             rl.Prepay,
+		HourlyRent:         rl.HourlyRent.String(),
+		DailyRent:          rl.DailyRent.String(),
+		Estimated:          rl.Estimated,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/protos/core"
+	justlendv1 "justlend/internal/protos/justlend/v1"
+)
+
+// toStatus converts the error of a call into its gRPC status error.
+func toStatus(err error) error {
+	return derrors.TogRPCSta(err).Err()
+}
+
+// failed returns the business-logic error of the endpoint response if any.
+func failed(response interface{}) error {
+	if f, ok := response.(interface{ Failed() error }); ok {
+		return f.Failed()
+	}
+	return nil
+}
+
+// The resource types of the API share the values of the Tron protocol.
+func resourceCode(t justlendv1.ResourceType) core.ResourceCode { return core.ResourceCode(t) }
+func resourceType(c core.ResourceCode) justlendv1.ResourceType { return justlendv1.ResourceType(c) }
+
+func toReceipt(rl *justlend.TransactionRL) *justlendv1.Receipt {
+	if rl == nil {
+		return nil
+	}
+	return &justlendv1.Receipt{
+		TxId:           rl.TxId,
+		Status:         rl.Status,
+		BlockNumber:    rl.BlockNumber,
+		BlockTimestamp: rl.BlockTimestamp,
+		Fee:            rl.Fee,
+		EnergyUsed:     rl.EnergyUsed,
+		EnergyFee:      rl.EnergyFee,
+		NetUsed:        rl.NetUsed,
+		NetFee:         rl.NetFee,
+		ContractResult: rl.ContractResult,
+		ContractOutput: rl.ContractOutput,
+		RevertReason:   rl.RevertReason,
+	}
+}
+
+var (
+	_ justlendv1.JustLendServer = (*Server)(nil)
+)
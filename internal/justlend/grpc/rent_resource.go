@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	justlendv1 "justlend/internal/protos/justlend/v1"
+)
+
+// Rent implements justlendv1.JustLendServer.
+func (s *Server) Rent(ctx context.Context, req *justlendv1.RentRequest) (*justlendv1.RentReply, error) {
+	_, rep, err := s.rent.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rep.(*justlendv1.RentReply), nil
+}
+
+func decodeRentRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(*justlendv1.RentRequest)
+	return &endpoints.RentResourceRequest{RentResourceMeta: &justlend.RentResourceMeta{
+		Receive:    req.GetReceiver(),
+		Type:       resourceCode(req.GetType()),
+		Amount:     req.GetAmount(),
+		Wallet:     req.GetWallet(),
+		PrivateKey: req.GetPrivateKey(),
+		Duration:   req.GetDuration(),
+		Prepay:     req.GetPrepay(),
+		Wait:       req.GetWait(),
+	}}, nil
+}
+
+func encodeRentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	if err := failed(response); err != nil {
+		return nil, err
+	}
+	rl := response.(endpoints.Response).Result.(*justlend.RentResourceRL)
+	rep := &justlendv1.RentReply{
+		OrderId:     rl.OrderId,
+		TxId:        rl.TxId,
+		StakePerTrx: rl.StakePerTrx,
+		Receipt:     toReceipt(rl.Receipt),
+	}
+	if rl.ReturnAt != nil {
+		rep.ReturnAt = rl.ReturnAt.Unix()
+	}
+	return rep, nil
+}
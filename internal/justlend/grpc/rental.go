@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	protov2 "google.golang.org/protobuf/proto"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	justlendv1 "justlend/internal/protos/justlend/v1"
+	"time"
+)
+
+// defaultWatchInterval is the interval between two checks of a watched
+// rental unless the request sets one.
+const defaultWatchInterval = 10 * time.Second
+
+// GetRental implements justlendv1.JustLendServer.
+func (s *Server) GetRental(ctx context.Context, req *justlendv1.GetRentalRequest) (*justlendv1.Rental, error) {
+	rental, err := s.getRental(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rental, nil
+}
+
+func (s *Server) getRental(ctx context.Context, req *justlendv1.GetRentalRequest) (*justlendv1.Rental, error) {
+	_, rep, err := s.rental.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*justlendv1.Rental), nil
+}
+
+// WatchRental implements justlendv1.JustLendServer, the rental is polled
+// every interval & sent whenever it changes.
+func (s *Server) WatchRental(req *justlendv1.WatchRentalRequest, stream justlendv1.JustLend_WatchRentalServer) error {
+	if req.GetInterval() < 0 {
+		return toStatus(derrors.InvalidParam)
+	}
+	interval := time.Duration(req.GetInterval()) * time.Second
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	ctx := stream.Context()
+	get := &justlendv1.GetRentalRequest{Renter: req.GetRenter(), Receiver: req.GetReceiver(), Type: req.GetType()}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	var last *justlendv1.Rental
+	for {
+		rental, err := s.getRental(ctx, get)
+		switch {
+		case errors.Is(err, derrors.NotFound) && last != nil:
+			// The rental was returned.
+			return nil
+		case err != nil:
+			return toStatus(err)
+		case !protov2.Equal(rental, last):
+			if err = stream.Send(rental); err != nil {
+				return err
+			}
+			last = rental
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+func decodeGetRentalRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(*justlendv1.GetRentalRequest)
+	rt := resourceCode(req.GetType())
+	return &endpoints.RentalRequest{RentalMeta: &justlend.RentalMeta{
+		Renter:   req.GetRenter(),
+		Receiver: req.GetReceiver(),
+		Type:     &rt,
+	}}, nil
+}
+
+func encodeGetRentalResponse(_ context.Context, response interface{}) (interface{}, error) {
+	if err := failed(response); err != nil {
+		return nil, err
+	}
+	rls := response.(endpoints.Response).Result.([]*justlend.RentalRL)
+	if len(rls) == 0 {
+		return nil, derrors.NotFound
+	}
+	rl := rls[0]
+	return &justlendv1.Rental{
+		Renter:             rl.Renter,
+		Receiver:           rl.Receiver,
+		Type:               resourceType(rl.Type),
+		StakePerTrx:        rl.StakePerTrx,
+		SecurityDeposit:    rl.SecurityDeposit.String(),
+		AccruedFee:         rl.AccruedFee.String(),
+		RemainingDeposit:   rl.RemainingDeposit.String(),
+		LiquidateThreshold: rl.LiquidateThreshold.String(),
+		Liquidatable:       rl.Liquidatable,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	justlendv1 "justlend/internal/protos/justlend/v1"
+)
+
+// Return implements justlendv1.JustLendServer.
+func (s *Server) Return(ctx context.Context, req *justlendv1.ReturnRequest) (*justlendv1.ReturnReply, error) {
+	_, rep, err := s.ret.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return rep.(*justlendv1.ReturnReply), nil
+}
+
+func decodeReturnRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(*justlendv1.ReturnRequest)
+	return &endpoints.ReturnResourceRequest{ReturnResourceMeta: &justlend.ReturnResourceMeta{
+		Receive:     req.GetReceiver(),
+		Type:        resourceCode(req.GetType()),
+		StakePerTrx: req.GetStakePerTrx(),
+		Wallet:      req.GetWallet(),
+		PrivateKey:  req.GetPrivateKey(),
+		Wait:        req.GetWait(),
+	}}, nil
+}
+
+func encodeReturnResponse(_ context.Context, response interface{}) (interface{}, error) {
+	if err := failed(response); err != nil {
+		return nil, err
+	}
+	rl := response.(endpoints.Response).Result.(*justlend.ReturnResourceRL)
+	return &justlendv1.ReturnReply{
+		OrderId:     rl.OrderId,
+		TxId:        rl.TxId,
+		StakePerTrx: rl.StakePerTrx,
+		Receipt:     toReceipt(rl.Receipt),
+	}, nil
+}
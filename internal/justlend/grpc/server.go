@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"github.com/go-kit/kit/transport"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"justlend/internal/config"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"justlend/internal/log"
+	justlendv1 "justlend/internal/protos/justlend/v1"
+	"net"
+	"time"
+)
+
+// Server represents the gRPC server of the justlend.v1 API, which serves the
+// same go-kit endpoints as the HTTP server.
+type Server struct {
+	justlendv1.UnimplementedJustLendServer
+
+	ln     net.Listener
+	server *grpc.Server
+
+	// Justlend service used by the handlers.
+	service justlend.Service
+
+	// apiKeyEnabled requires the API keys, adminAPIKey is the root key.
+	apiKeyEnabled bool
+	adminAPIKey   string
+
+	// Handlers of the unary calls, WatchRental polls the rental handler.
+	quote, rent, ret, rental grpctransport.Handler
+
+	// Bind address for the server's listener.
+	addr string
+
+	// ShutdownTimeout is the time given for outstanding calls to finish before
+	// shutdown, the watching streams are cut off then.
+	ShutdownTimeout time.Duration
+}
+
+// NewServer returns a new instance of Server.
+func NewServer(service justlend.Service, c *config.Config) *Server {
+	s := &Server{
+		addr:            c.GRPCAddr,
+		service:         service,
+		apiKeyEnabled:   c.APIKeyEnabled,
+		adminAPIKey:     c.AdminAPIKey,
+		ShutdownTimeout: c.GracefulTimeout,
+	}
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpctransport.Interceptor, s.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamInterceptor),
+	)
+	opts := []grpctransport.ServerOption{
+		grpctransport.ServerErrorHandler(transport.ErrorHandlerFunc(func(_ context.Context, err error) {
+			log.Errorf("%v", err)
+		})),
+	}
+	s.quote = grpctransport.NewServer(
+		endpoints.MakeFeeRatioEndpoint(service), decodeQuoteRequest, encodeQuoteResponse, opts...)
+	s.rent = grpctransport.NewServer(
+		endpoints.MakeRentResourceEndpoint(service), decodeRentRequest, encodeRentResponse, opts...)
+	s.ret = grpctransport.NewServer(
+		endpoints.MakeReturnResourceEndpoint(service), decodeReturnRequest, encodeReturnResponse, opts...)
+	s.rental = grpctransport.NewServer(
+		endpoints.MakeRentalsEndpoint(service), decodeGetRentalRequest, encodeGetRentalResponse, opts...)
+	justlendv1.RegisterJustLendServer(s.server, s)
+	return s
+}
+
+// Port returns the TCP port for the running server.
+// This is useful in tests where we allocate a random port by using ":0".
+func (s *Server) Port() int {
+	if s.ln == nil {
+		return 0
+	}
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+// Open begins listening on the bind address & serves the calls until the
+// server is closed.
+func (s *Server) Open() (err error) {
+	if s.ln, err = net.Listen("tcp", s.addr); err != nil {
+		return err
+	}
+	return s.server.Serve(s.ln)
+}
+
+// Close gracefully shuts down the server, the calls still running after the
+// ShutdownTimeout are canceled.
+func (s *Server) Close() error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(s.ShutdownTimeout):
+		s.server.Stop()
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerAPIKeyRouters(r *mux.Router) {
+	r.Methods(http.MethodPost).Path("/apikeys").Handler(httptransport.NewServer(
+		endpoints.MakeIssueAPIKeyEndpoint(s.service),
+		decodeIssueAPIKeyRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodGet).Path("/apikeys").Handler(httptransport.NewServer(
+		endpoints.MakeAPIKeysEndpoint(s.service),
+		decodeAPIKeysRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodDelete).Path("/apikeys/{id}").Handler(httptransport.NewServer(
+		endpoints.MakeRevokeAPIKeyEndpoint(s.service),
+		decodeRevokeAPIKeyRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			签发API密钥.
+// @Description		签发API密钥, 密钥仅在签发时返回一次, 只保存其哈希
+// @Tags			管理
+// @Accept			json
+// @Produce			json
+// @Security		ApiKeyAuth
+// @Param			name			body		string		true	"名称"
+// @Param			scopes			body		[]string	true	"权限范围(quote/rent/return/admin)"
+// @Param			wallets			body		[]string	false	"允许的扣费钱包ID或地址(为空不限制)"
+// @Param			receivers		body		[]string	false	"允许的接收地址(为空不限制)"
+// @Success			1000			{object}	justlend.APIKeyRL
+// @Router			/admin/apikeys [POST]
+func decodeIssueAPIKeyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.APIKeyMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return &endpoints.IssueAPIKeyRequest{APIKeyMeta: &req}, nil
+}
+
+// @Summary			API密钥列表.
+// @Description		查询已签发的API密钥, 包括已吊销的
+// @Tags			管理
+// @Produce			json
+// @Security		ApiKeyAuth
+// @Param			offset			query		int			false	"偏移"
+// @Param			limit			query		int			false	"数量(默认20, 最大100)"
+// @Success			1000			{array}		justlend.APIKeyRL
+// @Router			/admin/apikeys [GET]
+func decodeAPIKeysRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.APIKeysRequest{
+		APIKeysMeta: &justlend.APIKeysMeta{
+			Offset: safeExtractQueryUint(r, "offset"),
+			Limit:  safeExtractQueryUint(r, "limit"),
+		},
+	}, nil
+}
+
+// @Summary			吊销API密钥.
+// @Description		吊销API密钥, 之后使用该密钥的请求认证失败
+// @Tags			管理
+// @Produce			json
+// @Security		ApiKeyAuth
+// @Param			id				path		string		true	"密钥ID"
+// @Success			1000
+// @Router			/admin/apikeys/{id} [DELETE]
+func decodeRevokeAPIKeyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.RevokeAPIKeyRequest{
+		APIKeyIdMeta: &justlend.APIKeyIdMeta{Id: mux.Vars(r)["id"]},
+	}, nil
+}
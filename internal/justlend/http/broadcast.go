@@ -0,0 +1,38 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerBroadcastRouters(r *mux.Router) {
+	r.Methods(http.MethodPost).Path("/broadcast").Handler(httptransport.NewServer(
+		endpoints.MakeBroadcastEndpoint(s.service),
+		decodeBroadcastRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			广播离线签名交易.
+// @Description		广播离线签名交易, 未签名交易对应的订单随之更新
+// @Tags			交易
+// @Accept			json
+// @Produce			json
+// @Param			transaction		body		string		true	"已签名交易(hex)"
+// @Param			wait			query		bool		false	"等待交易确认"
+// @Success			1000			{object}	justlend.BroadcastRL
+// @Router			/broadcast [POST]
+func decodeBroadcastRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.BroadcastMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.Wait = safeExtractQueryBool(r, "wait")
+	return &endpoints.BroadcastRequest{BroadcastMeta: &req}, nil
+}
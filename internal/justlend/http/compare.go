@@ -0,0 +1,39 @@
+package http
+
+import (
+	"context"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerCompareRouters(r *mux.Router) {
+	r.Methods(http.MethodGet).Path("/fee/compare").Handler(httptransport.NewServer(
+		endpoints.MakeCompareEndpoint(s.service),
+		decodeCompareRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			费用对比.
+// @Description		对比租用、燃烧TRX与质押TRX获取能量的费用, 并给出建议
+// @Tags			费用计算
+// @Accept			json
+// @Produce			json
+// @Param			energy			query		int		true	"每天需要的能量"
+// @Param			owner			query		string	false	"使用能量的地址(可选), 用于判断余额是否足够质押"
+// @Param			duration		query		int		false	"需要能量的时长(秒), 默认172800"
+// @Success			1000			{object}	justlend.CompareRL
+// @Router			/fee/compare [GET]
+func decodeCompareRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.CompareRequest{
+		CompareMeta: &justlend.CompareMeta{
+			Energy:   safeExtractQueryInt(r, "energy"),
+			Owner:    safeExtractQueryString(r, "owner"),
+			Duration: safeExtractQueryInt(r, "duration"),
+		},
+	}, nil
+}
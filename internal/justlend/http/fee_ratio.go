@@ -4,6 +4,7 @@ import (
 	"context"
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
+	"justlend/internal/derrors"
 	"justlend/internal/justlend"
 	"justlend/internal/justlend/endpoints"
 	"justlend/internal/protos/core"
@@ -17,6 +18,12 @@ func (s *Server) registerFeeRatioRouters(r *mux.Router) {
 		encodeResponse,
 		s.opts...,
 	))
+	r.Methods(http.MethodGet).Path("/fee/schedule").Handler(httptransport.NewServer(
+		endpoints.MakeFeeScheduleEndpoint(s.service),
+		decodeFeeScheduleRequest,
+		encodeResponse,
+		s.opts...,
+	))
 }
 
 // @Summary			计算费用.
@@ -25,16 +32,49 @@ func (s *Server) registerFeeRatioRouters(r *mux.Router) {
 // @Accept			json
 // @Produce			json
 // @Param			energy			query		int		true	"需要速冲的数量"
-// @Param			privateKey		query		string	true	"私钥"
+// @Param			owner			query		string	false	"调用地址(可选)"
 // @Param			type			query		int32	true	"0(宽带),1(能量)"
+// @Param			prepay			query		int		false	"预付租金时长(秒), 默认172800"
+// @Param			partial			query		bool	false	"合约参数读取失败时使用最近的值估算"
 // @Success			1000			{object}	justlend.FeeRatioRL
 // @Router			/fee [GET]
 func decodeFeeRatioRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	return &endpoints.FeeRatioRequest{
 		FeeRatioMeta: &justlend.FeeRatioMeta{
-			Energy:     safeExtractQueryInt(r, "energy"),
-			PrivateKey: safeExtractQueryString(r, "privateKey"),
-			Type:       core.ResourceCode(safeExtractQueryInt(r, "type")),
+			Energy:  safeExtractQueryInt(r, "energy"),
+			Owner:   safeExtractQueryString(r, "owner"),
+			Type:    core.ResourceCode(safeExtractQueryInt(r, "type")),
+			Prepay:  safeExtractQueryInt(r, "prepay"),
+			Partial: safeExtractQueryBool(r, "partial"),
 		},
 	}, nil
 }
+
+// @Summary			租用费用预测.
+// @Description		预测不同租用时长的总费用
+// @Tags			费用计算
+// @Accept			json
+// @Produce			json
+// @Param			energy			query		int		true	"需要速冲的数量"
+// @Param			owner			query		string	false	"调用地址(可选)"
+// @Param			type			query		int32	true	"0(宽带),1(能量)"
+// @Param			durations		query		string	false	"租用时长(秒), 逗号分隔"
+// @Success			1000			{object}	justlend.FeeScheduleRL
+// @Router			/fee/schedule [GET]
+func decodeFeeScheduleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := &justlend.FeeScheduleMeta{
+		Energy: safeExtractQueryInt(r, "energy"),
+		Owner:  safeExtractQueryString(r, "owner"),
+		Type:   core.ResourceCode(safeExtractQueryInt(r, "type")),
+	}
+	if s := safeExtractQueryString(r, "durations"); s != "" {
+		ids := parseIDs(s)
+		if ids == nil {
+			return nil, derrors.InvalidParam
+		}
+		for _, d := range ids {
+			req.Durations = append(req.Durations, int64(d))
+		}
+	}
+	return &endpoints.FeeScheduleRequest{FeeScheduleMeta: req}, nil
+}
@@ -107,6 +107,14 @@ func safeExtractQueryBoolPtr(r *http.Request, name string) *bool {
 	return nil
 }
 
+// safeExtractQueryBool safely extract the variable of type bool from the request
+// query fields whose name is given in param, any value accepted by strconv.ParseBool
+// is allowed, or return false as the default value if an error occurs during extracting.
+func safeExtractQueryBool(r *http.Request, name string) (v bool) {
+	v, _ = strconv.ParseBool(r.URL.Query().Get(name))
+	return
+}
+
 // safeExtractQueryUint safely extract the variable of type uint64 from the request
 // query fields whose name is given in param, or return the default value
 // of 0 if an error occurs during extracting.
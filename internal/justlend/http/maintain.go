@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerMaintainRouters(r *mux.Router) {
+	r.Methods(http.MethodPost).Path("/maintain").Handler(httptransport.NewServer(
+		endpoints.MakeCreateMaintainEndpoint(s.service),
+		decodeCreateMaintainRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodGet).Path("/maintain").Handler(httptransport.NewServer(
+		endpoints.MakeMaintainsEndpoint(s.service),
+		decodeMaintainsRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodGet).Path("/maintain/{id}").Handler(httptransport.NewServer(
+		endpoints.MakeGetMaintainEndpoint(s.service),
+		decodeMaintainRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodPut).Path("/maintain/{id}").Handler(httptransport.NewServer(
+		endpoints.MakeUpdateMaintainEndpoint(s.service),
+		decodeUpdateMaintainRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodDelete).Path("/maintain/{id}").Handler(httptransport.NewServer(
+		endpoints.MakeDeleteMaintainEndpoint(s.service),
+		decodeDeleteMaintainRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			创建能量维持策略.
+// @Description		维持接收地址的可用能量不低于目标值, 不足时租用, 超出时退还
+// @Tags			能量维持
+// @Accept			json
+// @Produce			json
+// @Param			receiver		body		string		true	"接收地址"
+// @Param			wallet			body		string		true	"扣费钱包ID或地址"
+// @Param			target			body		int			true	"目标能量"
+// @Param			tolerance		body		int			false	"超出目标多少后退还(默认目标的1/10)"
+// @Param			maxSpend		body		int			true	"预付费用预算(SUN)"
+// @Param			paused			body		bool		false	"暂停"
+// @Success			1000			{object}	justlend.MaintainPolicyRL
+// @Router			/maintain [POST]
+func decodeCreateMaintainRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.MaintainPolicyMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return &endpoints.CreateMaintainRequest{MaintainPolicyMeta: &req}, nil
+}
+
+// @Summary			能量维持策略列表.
+// @Description		查询能量维持策略
+// @Tags			能量维持
+// @Produce			json
+// @Param			offset			query		int			false	"偏移"
+// @Param			limit			query		int			false	"数量(默认20, 最大100)"
+// @Success			1000			{array}		justlend.MaintainPolicyRL
+// @Router			/maintain [GET]
+func decodeMaintainsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.MaintainsRequest{
+		MaintainsMeta: &justlend.MaintainsMeta{
+			Offset: safeExtractQueryUint(r, "offset"),
+			Limit:  safeExtractQueryUint(r, "limit"),
+		},
+	}, nil
+}
+
+// @Summary			能量维持策略详情.
+// @Description		查询能量维持策略及最近一次检查结果
+// @Tags			能量维持
+// @Produce			json
+// @Param			id				path		string		true	"策略ID"
+// @Success			1000			{object}	justlend.MaintainPolicyRL
+// @Router			/maintain/{id} [GET]
+func decodeMaintainRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.MaintainRequest{
+		MaintainMeta: &justlend.MaintainMeta{Id: mux.Vars(r)["id"]},
+	}, nil
+}
+
+// @Summary			修改能量维持策略.
+// @Description		替换能量维持策略的设置, 已花费的费用保留
+// @Tags			能量维持
+// @Accept			json
+// @Produce			json
+// @Param			id				path		string		true	"策略ID"
+// @Param			receiver		body		string		true	"接收地址"
+// @Param			wallet			body		string		true	"扣费钱包ID或地址"
+// @Param			target			body		int			true	"目标能量"
+// @Param			tolerance		body		int			false	"超出目标多少后退还(默认目标的1/10)"
+// @Param			maxSpend		body		int			true	"预付费用预算(SUN)"
+// @Param			paused			body		bool		false	"暂停"
+// @Success			1000			{object}	justlend.MaintainPolicyRL
+// @Router			/maintain/{id} [PUT]
+func decodeUpdateMaintainRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.UpdateMaintainMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req.MaintainPolicyMeta); e != nil {
+		return nil, e
+	}
+	req.Id = mux.Vars(r)["id"]
+	return &endpoints.UpdateMaintainRequest{UpdateMaintainMeta: &req}, nil
+}
+
+// @Summary			删除能量维持策略.
+// @Description		删除能量维持策略, 已租用的能量保留
+// @Tags			能量维持
+// @Produce			json
+// @Param			id				path		string		true	"策略ID"
+// @Success			1000
+// @Router			/maintain/{id} [DELETE]
+func decodeDeleteMaintainRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return decodeMaintainRequest(ctx, r)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"justlend/internal/config"
 	"justlend/internal/derrors"
+	"justlend/internal/justlend"
 	"justlend/internal/log"
 	"net/http"
 	"time"
@@ -16,8 +17,6 @@ const (
 	defaultApikeyHeader = "X-APIKEY"
 )
 
-var eKeyEnabled = config.GetEnvBool("ENCRYPT_KEY_ENABLED", true)
-
 // Timeout returns a new middleware that times out each request after the given duration.
 func (s *Server) timeout(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -43,6 +42,29 @@ func (s *Server) catchPanic(next http.Handler) http.Handler {
 	})
 }
 
+// authenticate returns a middleware which authenticates the API key of the
+// requests, the key must have any of the scopes. It does nothing if the keys
+// are disabled.
+func (s *Server) authenticate(scopes ...justlend.APIKeyScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !s.apiKeyEnabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+			k, err := justlend.Authenticate(r.Context(), s.service, s.adminAPIKey, r.Header.Get(defaultApikeyHeader))
+			if err != nil {
+				encodeError(r.Context(), err, w)
+				return
+			} else if !k.Allows(scopes...) {
+				encodeError(r.Context(), derrors.Forbidden, w)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(justlend.WithAPIKey(r.Context(), k)))
+		})
+	}
+}
+
 // Defines max length limit for request URI.
 const maxURILength = 1000
 
@@ -64,7 +86,7 @@ func (s *Server) corsOptions(h http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", origin)
 		//w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, X-SF-Language, Content-Type, Accept, User-Agent, Authorization, X-Requested-With, x-request-passcode")
+		w.Header().Set("Access-Control-Allow-Headers", "Origin, X-SF-Language, Content-Type, Accept, User-Agent, Authorization, X-Requested-With, x-request-passcode, "+defaultApikeyHeader)
 		//w.Header().Set("Access-Control-Max-Age", "86400")
 		//w.Header().Set("X-Content-Type-Options", "nosniff") // Prevent MIME sniffing.
 		//w.Header().Set("X-Frame-Options", "deny")           // Don't allow frame embedding.
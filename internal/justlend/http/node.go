@@ -0,0 +1,28 @@
+package http
+
+import (
+	"context"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerNodeRouters(r *mux.Router) {
+	r.Methods(http.MethodGet).Path("/nodes").Handler(httptransport.NewServer(
+		endpoints.MakeNodesEndpoint(s.service),
+		decodeNodesRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			节点状态.
+// @Description		查询Tron节点的健康状态
+// @Tags			管理
+// @Produce			json
+// @Success			1000			{array}		justlend.NodeRL
+// @Router			/admin/nodes [GET]
+func decodeNodesRequest(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerOrderRouters(r *mux.Router) {
+	r.Methods(http.MethodGet).Path("/orders").Handler(httptransport.NewServer(
+		endpoints.MakeOrdersEndpoint(s.service),
+		decodeOrdersRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodGet).Path("/orders/{id}").Handler(httptransport.NewServer(
+		endpoints.MakeOrderEndpoint(s.service),
+		decodeOrderRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodGet).Path("/orders/{id}/events").Handler(httptransport.NewServer(
+		endpoints.MakeOrderEventsEndpoint(s.service),
+		decodeOrderEventsRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			订单列表.
+// @Description		查询账本中的报价、租赁及退还订单, 按创建时间倒序
+// @Tags			订单
+// @Produce			json
+// @Param			owner			query		string		false	"付款地址"
+// @Param			receiver		query		string		false	"接收地址"
+// @Param			kind			query		string		false	"quote, rent, return"
+// @Param			status			query		string		false	"created, broadcast, confirmed, failed, returned"
+// @Param			txId			query		string		false	"交易ID"
+// @Param			offset			query		int			false	"偏移"
+// @Param			limit			query		int			false	"数量(默认20, 最大100)"
+// @Success			1000			{array}		justlend.OrderRL
+// @Router			/orders [GET]
+func decodeOrdersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.OrdersRequest{
+		OrdersMeta: &justlend.OrdersMeta{
+			Owner:    safeExtractQueryString(r, "owner"),
+			Receiver: safeExtractQueryString(r, "receiver"),
+			Kind:     justlend.OrderKind(safeExtractQueryString(r, "kind")),
+			Status:   justlend.OrderStatus(safeExtractQueryString(r, "status")),
+			TxId:     safeExtractQueryString(r, "txId"),
+			Offset:   safeExtractQueryUint(r, "offset"),
+			Limit:    safeExtractQueryUint(r, "limit"),
+		},
+	}, nil
+}
+
+// @Summary			订单详情.
+// @Description		查询账本中的订单
+// @Tags			订单
+// @Produce			json
+// @Param			id				path		string		true	"订单ID"
+// @Success			1000			{object}	justlend.OrderRL
+// @Router			/orders/{id} [GET]
+func decodeOrderRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.OrderRequest{
+		OrderMeta: &justlend.OrderMeta{Id: mux.Vars(r)["id"]},
+	}, nil
+}
+
+// @Summary			订单状态变更.
+// @Description		按时间顺序查询订单的状态变更记录
+// @Tags			订单
+// @Produce			json
+// @Param			id				path		string		true	"订单ID"
+// @Success			1000			{array}		justlend.OrderEventRL
+// @Router			/orders/{id}/events [GET]
+func decodeOrderEventsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return decodeOrderRequest(ctx, r)
+}
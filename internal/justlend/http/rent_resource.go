@@ -17,6 +17,12 @@ func (s *Server) registerRentResourceRouters(r *mux.Router) {
 		encodeResponse,
 		s.opts...,
 	))
+	r.Methods(http.MethodPost).Path("/rent/unsigned").Handler(httptransport.NewServer(
+		endpoints.MakeRentResourceEndpoint(s.service),
+		decodeUnsignedRentResourceRequest,
+		encodeResponse,
+		s.opts...,
+	))
 }
 
 // @Summary			租用.
@@ -27,7 +33,13 @@ func (s *Server) registerRentResourceRouters(r *mux.Router) {
 // @Param			receive			body		string		true	"速冲地址"
 // @Param			type			body		int			true	"速冲类型0(宽带),1(能量)"
 // @Param			amount			body		int			true	"速冲数量"
-// @Param			privateKey		body		string		true	"扣费私钥"
+// @Param			wallet			body		string		false	"扣费钱包ID或地址"
+// @Param			privateKey		body		string		false	"扣费私钥(未启用加密密钥时)"
+// @Param			duration		body		int			false	"租赁时长(秒), 到期自动退还, 仅支持钱包"
+// @Param			prepay			body		int			false	"预付租金时长(秒), 默认172800"
+// @Param			wait			query		bool		false	"等待交易确认"
+// @Param			owner			body		string		false	"模拟的付款地址(仅dryRun, 无需钱包或私钥)"
+// @Param			dryRun			query		bool		false	"仅模拟并返回未签名交易, 不广播"
 // @Success			1000			{object}	justlend.RentResourceRL
 // @Router			/rent [POST]
 func decodeRentResourceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
@@ -35,5 +47,28 @@ func decodeRentResourceRequest(ctx context.Context, r *http.Request) (interface{
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
 		return nil, e
 	}
+	req.Wait = safeExtractQueryBool(r, "wait")
+	req.DryRun = safeExtractQueryBool(r, "dryRun")
+	return &endpoints.RentResourceRequest{RentResourceMeta: &req}, nil
+}
+
+// @Summary			构建未签名租用交易.
+// @Description		构建未签名租用交易, 离线签名后通过 /broadcast 广播
+// @Tags			交易
+// @Accept			json
+// @Produce			json
+// @Param			owner			body		string		true	"扣费地址"
+// @Param			receive			body		string		true	"速冲地址"
+// @Param			type			body		int			true	"速冲类型0(宽带),1(能量)"
+// @Param			amount			body		int			true	"速冲数量"
+// @Param			prepay			body		int			false	"预付租金时长(秒), 默认172800"
+// @Success			1000			{object}	justlend.RentResourceRL
+// @Router			/rent/unsigned [POST]
+func decodeUnsignedRentResourceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.RentResourceMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.Unsigned = true
 	return &endpoints.RentResourceRequest{RentResourceMeta: &req}, nil
 }
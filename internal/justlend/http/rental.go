@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"justlend/internal/protos/core"
+	"net/http"
+)
+
+func (s *Server) registerRentalRouters(r *mux.Router) {
+	r.Methods(http.MethodGet).Path("/rentals").Handler(httptransport.NewServer(
+		endpoints.MakeRentalsEndpoint(s.service),
+		decodeRentalRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			租赁查询.
+// @Description		查询租赁地址给接收地址的租赁及保证金状态
+// @Tags			租赁
+// @Produce			json
+// @Param			renter			query		string		true	"租赁地址"
+// @Param			receiver		query		string		true	"接收地址"
+// @Param			type			query		int32		false	"0(宽带),1(能量), 不传则查询全部"
+// @Success			1000			{array}		justlend.RentalRL
+// @Router			/rentals [GET]
+func decodeRentalRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	meta := &justlend.RentalMeta{
+		Renter:   safeExtractQueryString(r, "renter"),
+		Receiver: safeExtractQueryString(r, "receiver"),
+	}
+	if v := safeExtractQueryUintPtr(r, "type"); v != nil {
+		rt := core.ResourceCode(*v)
+		meta.Type = &rt
+	}
+	return &endpoints.RentalRequest{RentalMeta: meta}, nil
+}
@@ -17,6 +17,12 @@ func (s *Server) registerReturnResourceRouters(r *mux.Router) {
 		encodeResponse,
 		s.opts...,
 	))
+	r.Methods(http.MethodPost).Path("/return/unsigned").Handler(httptransport.NewServer(
+		endpoints.MakeReturnResourceEndpoint(s.service),
+		decodeUnsignedReturnResourceRequest,
+		encodeResponse,
+		s.opts...,
+	))
 }
 
 // @Summary			退款.
@@ -26,8 +32,12 @@ func (s *Server) registerReturnResourceRouters(r *mux.Router) {
 // @Produce			json
 // @Param			receive			body		string		true	"速冲地址"
 // @Param			type			body		int			true	"速冲类型0(宽带),1(能量)"
-// @Param			stakePerTrx		body		int			true	"退款数量"
-// @Param			privateKey		body		string		true	"扣费私钥"
+// @Param			stakePerTrx		body		int			false	"退款数量(SUN), 不传则退还全部"
+// @Param			wallet			body		string		false	"扣费钱包ID或地址"
+// @Param			privateKey		body		string		false	"扣费私钥(未启用加密密钥时)"
+// @Param			wait			query		bool		false	"等待交易确认"
+// @Param			owner			body		string		false	"模拟的付款地址(仅dryRun, 无需钱包或私钥)"
+// @Param			dryRun			query		bool		false	"仅模拟并返回未签名交易, 不广播"
 // @Success			1000			{object}	justlend.ReturnResourceRL
 // @Router			/return [POST]
 func decodeReturnResourceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
@@ -35,5 +45,27 @@ func decodeReturnResourceRequest(ctx context.Context, r *http.Request) (interfac
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
 		return nil, e
 	}
+	req.Wait = safeExtractQueryBool(r, "wait")
+	req.DryRun = safeExtractQueryBool(r, "dryRun")
+	return &endpoints.ReturnResourceRequest{ReturnResourceMeta: &req}, nil
+}
+
+// @Summary			构建未签名退款交易.
+// @Description		构建未签名退款交易, 离线签名后通过 /broadcast 广播
+// @Tags			交易
+// @Accept			json
+// @Produce			json
+// @Param			owner			body		string		true	"扣费地址"
+// @Param			receive			body		string		true	"速冲地址"
+// @Param			type			body		int			true	"速冲类型0(宽带),1(能量)"
+// @Param			stakePerTrx		body		int			false	"退款数量(SUN), 不传则退还全部"
+// @Success			1000			{object}	justlend.ReturnResourceRL
+// @Router			/return/unsigned [POST]
+func decodeUnsignedReturnResourceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.ReturnResourceMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.Unsigned = true
 	return &endpoints.ReturnResourceRequest{ReturnResourceMeta: &req}, nil
 }
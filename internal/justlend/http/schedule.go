@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerScheduleRouters(r *mux.Router) {
+	r.Methods(http.MethodGet).Path("/schedules").Handler(httptransport.NewServer(
+		endpoints.MakeSchedulesEndpoint(s.service),
+		decodeSchedulesRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodDelete).Path("/schedules/{orderId}").Handler(httptransport.NewServer(
+		endpoints.MakeCancelScheduleEndpoint(s.service),
+		decodeCancelScheduleRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			自动退还列表.
+// @Description		查询定时自动退还的租赁, 按退还时间排序
+// @Tags			订单
+// @Produce			json
+// @Param			status			query		string		false	"pending, done, failed, cancelled"
+// @Param			offset			query		int			false	"偏移"
+// @Param			limit			query		int			false	"数量(默认20, 最大100)"
+// @Success			1000			{array}		justlend.ScheduleRL
+// @Router			/schedules [GET]
+func decodeSchedulesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.SchedulesRequest{
+		SchedulesMeta: &justlend.SchedulesMeta{
+			Status: justlend.ScheduleStatus(safeExtractQueryString(r, "status")),
+			Offset: safeExtractQueryUint(r, "offset"),
+			Limit:  safeExtractQueryUint(r, "limit"),
+		},
+	}, nil
+}
+
+// @Summary			取消自动退还.
+// @Description		取消租赁订单的定时自动退还, 租赁将保留
+// @Tags			订单
+// @Produce			json
+// @Param			orderId			path		string		true	"租赁订单ID"
+// @Success			1000			{object}	justlend.ScheduleRL
+// @Router			/schedules/{orderId} [DELETE]
+func decodeCancelScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.ScheduleRequest{
+		ScheduleMeta: &justlend.ScheduleMeta{OrderId: mux.Vars(r)["orderId"]},
+	}, nil
+}
@@ -32,6 +32,10 @@ type Server struct {
 	hashKey  []byte
 	blockKey []byte
 
+	// apiKeyEnabled requires the API keys, adminAPIKey is the root key.
+	apiKeyEnabled bool
+	adminAPIKey   string
+
 	// Justlend service used by the various HTTP routes.
 	service justlend.Service
 
@@ -56,14 +60,17 @@ func NewServer(service justlend.Service, c *config.Config) *Server {
 		domain:  c.Domain,
 		service: service,
 		server: &http.Server{
-			// Set timeouts to avoid Slow-loris attacks.
-			WriteTimeout: time.Second * 15,
+			// Set timeouts to avoid Slow-loris attacks, the write timeout
+			// covers the requests waiting for the transaction confirmations.
+			WriteTimeout: time.Second * time.Duration(config.GetEnvInt("HTTP_REQUEST_TIMEOUT", defaultRequestTimeout)+15),
 			ReadTimeout:  time.Second * 15,
 			IdleTimeout:  time.Second * 60,
 		},
 		router:          mux.NewRouter(),
 		hashKey:         c.SCHashKey,
 		blockKey:        c.SCBlockKey,
+		apiKeyEnabled:   c.APIKeyEnabled,
+		adminAPIKey:     c.AdminAPIKey,
 		ShutdownTimeout: c.GracefulTimeout,
 		opts: []kithttp.ServerOption{
 			kithttp.ServerErrorHandler(transport.ErrorHandlerFunc(func(_ context.Context, err error) {
@@ -93,14 +100,49 @@ func (s *Server) RegisterRoutes() {
 
 	// Set up a base router that excludes debug handling.
 	router := s.router.PathPrefix("/").Subrouter()
-	// Extract info's from request header and fill into request context.
-	// Register unauthenticated routes.
+	// Authenticate the API key from request header and fill it into request
+	// context, the routes are grouped by the scopes they require.
+	// Register the read-only routes.
 	{
 		r := router.PathPrefix("/").Subrouter()
+		r.Use(s.authenticate(justlend.ScopeQuote))
 		s.registerFeeRatioRouters(r)
+		s.registerCompareRouters(r)
+		s.registerTransactionRouters(r)
+		s.registerRentalRouters(r)
+	}
+	// Register the rent & return routes.
+	{
+		r := router.PathPrefix("/").Subrouter()
+		r.Use(s.authenticate(justlend.ScopeRent))
 		s.registerRentResourceRouters(r)
+		s.registerScheduleRouters(r)
+	}
+	{
+		r := router.PathPrefix("/").Subrouter()
+		r.Use(s.authenticate(justlend.ScopeReturn))
 		s.registerReturnResourceRouters(r)
 	}
+	{
+		r := router.PathPrefix("/").Subrouter()
+		r.Use(s.authenticate(justlend.ScopeRent, justlend.ScopeReturn))
+		s.registerBroadcastRouters(r)
+		s.registerOrderRouters(r)
+	}
+	// Register admin routes.
+	{
+		r := router.PathPrefix("/").Subrouter()
+		r.Use(s.authenticate(justlend.ScopeAdmin))
+		s.registerWatchRouters(r)
+		s.registerMaintainRouters(r)
+		s.registerTransferRouters(r)
+	}
+	{
+		r := router.PathPrefix("/admin").Subrouter()
+		r.Use(s.authenticate(justlend.ScopeAdmin))
+		s.registerNodeRouters(r)
+		s.registerAPIKeyRouters(r)
+	}
 
 	// Our router is wrapped by another function handler to perform some
 	// middleware-like tasks that cannot be performed by actual middleware.
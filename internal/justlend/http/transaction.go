@@ -0,0 +1,32 @@
+package http
+
+import (
+	"context"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerTransactionRouters(r *mux.Router) {
+	r.Methods(http.MethodGet).Path("/tx/{id}").Handler(httptransport.NewServer(
+		endpoints.MakeTransactionEndpoint(s.service),
+		decodeTransactionRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			交易状态.
+// @Description		查询交易的确认状态及收据
+// @Tags			交易
+// @Produce			json
+// @Param			id				path		string		true	"交易ID"
+// @Success			1000			{object}	justlend.TransactionRL
+// @Router			/tx/{id} [GET]
+func decodeTransactionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.TransactionRequest{
+		TransactionMeta: &justlend.TransactionMeta{TxId: mux.Vars(r)["id"]},
+	}, nil
+}
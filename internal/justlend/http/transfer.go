@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"net/http"
+)
+
+func (s *Server) registerTransferRouters(r *mux.Router) {
+	r.Methods(http.MethodPost).Path("/transfer").Handler(httptransport.NewServer(
+		endpoints.MakeTransferEndpoint(s.service),
+		decodeTransferRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			TRC20转账.
+// @Description		TRC20转账, 先租用不足的能量, 转账确认后可退还
+// @Tags			交易
+// @Accept			json
+// @Produce			json
+// @Param			token			body		string		true	"TRC20合约地址"
+// @Param			to				body		string		true	"收款地址"
+// @Param			amount			body		string		true	"转账数量(最小单位)"
+// @Param			wallet			body		string		false	"转账钱包ID或地址"
+// @Param			privateKey		body		string		false	"转账私钥(未启用加密密钥时)"
+// @Param			return			body		bool		false	"转账后退还租用的能量"
+// @Success			1000			{object}	justlend.TransferRL
+// @Router			/transfer [POST]
+func decodeTransferRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.TransferMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return &endpoints.TransferRequest{TransferMeta: &req}, nil
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/endpoints"
+	"justlend/internal/protos/core"
+	"net/http"
+)
+
+func (s *Server) registerWatchRouters(r *mux.Router) {
+	r.Methods(http.MethodPut).Path("/watches").Handler(httptransport.NewServer(
+		endpoints.MakeSetWatchEndpoint(s.service),
+		decodeSetWatchRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodGet).Path("/watches").Handler(httptransport.NewServer(
+		endpoints.MakeWatchesEndpoint(s.service),
+		decodeWatchesRequest,
+		encodeResponse,
+		s.opts...,
+	))
+	r.Methods(http.MethodDelete).Path("/watches").Handler(httptransport.NewServer(
+		endpoints.MakeDeleteWatchEndpoint(s.service),
+		decodeDeleteWatchRequest,
+		encodeResponse,
+		s.opts...,
+	))
+}
+
+// @Summary			设置清算监控.
+// @Description		设置租赁的保证金监控策略, 保证金低于清算阈值加余量时告警或自动补充
+// @Tags			租赁
+// @Accept			json
+// @Produce			json
+// @Param			renter			body		string		true	"租赁地址"
+// @Param			receiver		body		string		true	"接收地址"
+// @Param			type			body		int			true	"0(宽带),1(能量)"
+// @Param			action			body		string		true	"alert(告警), topup(自动补充), none(不监控)"
+// @Param			margin			body		int			false	"清算阈值之上的余量(SUN)"
+// @Param			wallet			body		string		false	"补充保证金的钱包ID或地址(topup时必填)"
+// @Param			topUp			body		int			false	"每次补充的保证金(SUN, topup时必填)"
+// @Param			webhook			body		string		false	"告警地址, 不传则使用默认地址"
+// @Success			1000			{object}	justlend.WatchPolicyRL
+// @Router			/watches [PUT]
+func decodeSetWatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := justlend.WatchPolicyMeta{}
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return &endpoints.SetWatchRequest{WatchPolicyMeta: &req}, nil
+}
+
+// @Summary			清算监控列表.
+// @Description		查询租赁的保证金监控策略
+// @Tags			租赁
+// @Produce			json
+// @Param			offset			query		int			false	"偏移"
+// @Param			limit			query		int			false	"数量(默认20, 最大100)"
+// @Success			1000			{array}		justlend.WatchPolicyRL
+// @Router			/watches [GET]
+func decodeWatchesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.WatchesRequest{
+		WatchesMeta: &justlend.WatchesMeta{
+			Offset: safeExtractQueryUint(r, "offset"),
+			Limit:  safeExtractQueryUint(r, "limit"),
+		},
+	}, nil
+}
+
+// @Summary			删除清算监控.
+// @Description		删除租赁的保证金监控策略, 恢复默认策略
+// @Tags			租赁
+// @Produce			json
+// @Param			renter			query		string		true	"租赁地址"
+// @Param			receiver		query		string		true	"接收地址"
+// @Param			type			query		int32		true	"0(宽带),1(能量)"
+// @Success			1000
+// @Router			/watches [DELETE]
+func decodeDeleteWatchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &endpoints.DeleteWatchRequest{
+		WatchMeta: &justlend.WatchMeta{
+			Renter:   safeExtractQueryString(r, "renter"),
+			Receiver: safeExtractQueryString(r, "receiver"),
+			Type:     core.ResourceCode(safeExtractQueryInt(r, "type")),
+		},
+	}, nil
+}
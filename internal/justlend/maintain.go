@@ -0,0 +1,121 @@
+package justlend
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"time"
+)
+
+// MaintainPolicyMeta keeps the available energy of the receiver at the
+// target level, by renting the shortfall & returning the surplus.
+type MaintainPolicyMeta struct {
+	Receiver string `json:"receiver"`
+	// Wallet is the ID or address of the signer wallet that rents.
+	Wallet string `json:"wallet"`
+	// Target is the minimum available energy of the receiver, the surplus
+	// above Target+Tolerance is returned. Tolerance defaults to a tenth of
+	// the target if it's zero.
+	Target    int64 `json:"target"`
+	Tolerance int64 `json:"tolerance"`
+	// MaxSpend is the budget in SUN of the prepaid fees of the rentals.
+	MaxSpend int64 `json:"maxSpend"`
+	// Paused stops the policy without deleting it.
+	Paused bool `json:"paused"`
+}
+
+func (m *MaintainPolicyMeta) Conform(ctx context.Context) error {
+	switch {
+	case !internal.IsValidAddress(m.Receiver):
+		return derrors.InvalidParam
+	case internal.IsEmpty(m.Wallet):
+		return derrors.InvalidParam
+	case m.Target <= 0 || m.Tolerance < 0 || m.MaxSpend <= 0:
+		return derrors.InvalidParam
+	}
+	if err := conformAccess(ctx, m.Wallet, m.Receiver); err != nil {
+		return err
+	}
+	if m.Tolerance == 0 {
+		m.Tolerance = m.Target / 10
+	}
+	return nil
+}
+
+type MaintainPolicyRL struct {
+	Id        string `json:"id"`
+	Receiver  string `json:"receiver"`
+	Wallet    string `json:"wallet"`
+	Target    int64  `json:"target"`
+	Tolerance int64  `json:"tolerance"`
+	MaxSpend  int64  `json:"maxSpend"`
+	// Spent is the total prepaid fees in SUN of the rentals.
+	Spent  int64 `json:"spent"`
+	Paused bool  `json:"paused"`
+	// Available is the available energy of the receiver at CheckedAt.
+	Available int64      `json:"available"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	// LastOrderId is the last rent or return order of the policy.
+	LastOrderId string    `json:"lastOrderId,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type MaintainMeta struct {
+	Id string
+}
+
+func (m *MaintainMeta) Conform(_ context.Context) error {
+	if _, err := uuid.Parse(m.Id); err != nil {
+		return derrors.InvalidParam
+	}
+	return nil
+}
+
+type UpdateMaintainMeta struct {
+	MaintainMeta
+	MaintainPolicyMeta
+}
+
+func (m *UpdateMaintainMeta) Conform(ctx context.Context) error {
+	if err := m.MaintainMeta.Conform(ctx); err != nil {
+		return err
+	}
+	return m.MaintainPolicyMeta.Conform(ctx)
+}
+
+type MaintainsMeta struct {
+	// Offset & Limit select the page of the policies.
+	Offset, Limit uint64
+}
+
+func (m *MaintainsMeta) Conform(_ context.Context) error {
+	if m.Limit > maxOrderLimit {
+		return derrors.InvalidParam
+	} else if m.Limit == 0 {
+		m.Limit = 20
+	}
+	return nil
+}
+
+var (
+	_ internal.Conformer = (*MaintainPolicyMeta)(nil)
+	_ internal.Conformer = (*MaintainMeta)(nil)
+	_ internal.Conformer = (*UpdateMaintainMeta)(nil)
+	_ internal.Conformer = (*MaintainsMeta)(nil)
+)
+
+type MaintainService interface {
+	CreateMaintain(ctx context.Context, req *MaintainPolicyMeta) (*MaintainPolicyRL, error)
+	Maintains(ctx context.Context, req *MaintainsMeta) ([]*MaintainPolicyRL, int, error)
+	GetMaintain(ctx context.Context, req *MaintainMeta) (*MaintainPolicyRL, error)
+	// UpdateMaintain replaces the settings of the policy, the spent fees
+	// are kept.
+	UpdateMaintain(ctx context.Context, req *UpdateMaintainMeta) (*MaintainPolicyRL, error)
+	DeleteMaintain(ctx context.Context, req *MaintainMeta) error
+	// Maintain checks the receivers of the active policies once and rents
+	// or returns energy to keep them at the target levels.
+	Maintain(ctx context.Context) error
+}
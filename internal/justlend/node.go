@@ -0,0 +1,23 @@
+package justlend
+
+import (
+	"context"
+	"time"
+)
+
+type NodeRL struct {
+	Addr        string    `json:"addr"`
+	Healthy     bool      `json:"healthy"`
+	BlockHeight int64     `json:"blockHeight"`
+	Lag         int64     `json:"lag"`
+	Latency     int64     `json:"latency"`
+	Version     string    `json:"version,omitempty"`
+	Failures    int       `json:"failures"`
+	Error       string    `json:"error,omitempty"`
+	CheckedAt   time.Time `json:"checkedAt"`
+}
+
+type NodeService interface {
+	// Nodes returns the health status of the Tron nodes.
+	Nodes(ctx context.Context) ([]*NodeRL, error)
+}
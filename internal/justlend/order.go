@@ -0,0 +1,149 @@
+package justlend
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/core"
+	"time"
+)
+
+// OrderKind is the kind of the operation recorded by an order.
+type OrderKind string
+
+const (
+	OrderQuote  OrderKind = "quote"
+	OrderRent   OrderKind = "rent"
+	OrderReturn OrderKind = "return"
+	// OrderTopUp tops up the security deposit of a rental.
+	OrderTopUp OrderKind = "topup"
+)
+
+// OrderStatus is the status of an order, an order is created before its
+// transaction is signed, and moves along with the transaction:
+//
+//	created → broadcast → confirmed → returned
+//	   └──────────┴──────→ failed
+//
+// Only the confirmed rent orders are returned, quotes stay created since
+// they're never broadcast.
+type OrderStatus string
+
+const (
+	OrderCreated   OrderStatus = "created"
+	OrderBroadcast OrderStatus = "broadcast"
+	OrderConfirmed OrderStatus = "confirmed"
+	OrderFailed    OrderStatus = "failed"
+	OrderReturned  OrderStatus = "returned"
+)
+
+// orderTransitions holds the statuses an order may move into from each status.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderCreated:   {OrderBroadcast, OrderFailed},
+	OrderBroadcast: {OrderConfirmed, OrderFailed},
+	OrderConfirmed: {OrderReturned},
+}
+
+// CanTransition reports whether an order may move from s into the status.
+func (s OrderStatus) CanTransition(to OrderStatus) bool {
+	return internal.Contains(to, orderTransitions[s]...)
+}
+
+// Valid reports whether s is a known status.
+func (s OrderStatus) Valid() bool {
+	return internal.Contains(s, OrderCreated, OrderBroadcast, OrderConfirmed, OrderFailed, OrderReturned)
+}
+
+// Valid reports whether k is a known kind.
+func (k OrderKind) Valid() bool {
+	return internal.Contains(k, OrderQuote, OrderRent, OrderReturn, OrderTopUp)
+}
+
+type OrderRL struct {
+	Id       string            `json:"id"`
+	Kind     OrderKind         `json:"kind"`
+	Status   OrderStatus       `json:"status"`
+	Owner    string            `json:"owner"`
+	Receiver string            `json:"receiver,omitempty"`
+	Type     core.ResourceCode `json:"type"`
+	// Amount is the amount of the rented resource.
+	Amount int64 `json:"amount"`
+	// StakePerTrx & Fee are the staked TRX & the prepaid fee in SUN.
+	StakePerTrx int64      `json:"stakePerTrx"`
+	Fee         int64      `json:"fee"`
+	TxId        string     `json:"txId,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	// Error is the reason of the failed orders.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// OrderEventRL records a transition of an order.
+type OrderEventRL struct {
+	Status    OrderStatus `json:"status"`
+	TxId      string      `json:"txId,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// maxOrderLimit is the maximum number of orders of a page.
+const maxOrderLimit = 100
+
+type OrdersMeta struct {
+	Owner    string
+	Receiver string
+	Kind     OrderKind
+	Status   OrderStatus
+	TxId     string
+	// Offset & Limit select the page of the orders, the latest come first.
+	Offset, Limit uint64
+}
+
+func (m *OrdersMeta) Conform(_ context.Context) error {
+	switch {
+	case !internal.IsEmpty(m.Owner) && !internal.IsValidAddress(m.Owner):
+		return derrors.InvalidParam
+	case !internal.IsEmpty(m.Receiver) && !internal.IsValidAddress(m.Receiver):
+		return derrors.InvalidParam
+	case m.Kind != "" && !m.Kind.Valid():
+		return derrors.InvalidParam
+	case m.Status != "" && !m.Status.Valid():
+		return derrors.InvalidParam
+	case m.Limit > maxOrderLimit:
+		return derrors.InvalidParam
+	}
+	if m.Limit == 0 {
+		m.Limit = 20
+	}
+	return nil
+}
+
+type OrderMeta struct {
+	Id string
+}
+
+func (m *OrderMeta) Conform(_ context.Context) error {
+	if _, err := uuid.Parse(m.Id); err != nil {
+		return derrors.InvalidParam
+	}
+	return nil
+}
+
+var (
+	_ internal.Conformer = (*OrdersMeta)(nil)
+	_ internal.Conformer = (*OrderMeta)(nil)
+)
+
+type OrderService interface {
+	// Orders returns the page of the orders matching the filter along
+	// with the total count of them.
+	Orders(ctx context.Context, req *OrdersMeta) ([]*OrderRL, int, error)
+	// Order returns the order of the ID.
+	Order(ctx context.Context, req *OrderMeta) (*OrderRL, error)
+	// OrderEvents returns the transitions of the order in time order.
+	OrderEvents(ctx context.Context, req *OrderMeta) ([]*OrderEventRL, int, error)
+	// Reconcile settles the broadcast orders whose transactions are final.
+	Reconcile(ctx context.Context) error
+}
@@ -0,0 +1,21 @@
+package justlend
+
+import (
+	"justlend/internal"
+	"justlend/internal/config"
+	"justlend/internal/derrors"
+)
+
+// eKeyEnabled enforces that the requests refer to the encrypted keystore
+// wallets rather than carrying the raw private keys, whichever transport
+// they come from.
+var eKeyEnabled = config.GetEnvBool("ENCRYPT_KEY_ENABLED", true)
+
+// conformKey returns derrors.Forbidden if the encrypted keys are enforced
+// but the request carries a raw private key.
+func conformKey(privateKey string) error {
+	if eKeyEnabled && !internal.IsEmpty(privateKey) {
+		return derrors.Forbidden
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package justlend
+
+import (
+	"errors"
+	"justlend/internal/derrors"
+	"testing"
+)
+
+func TestConformKey(t *testing.T) {
+	const key = "8e812436a0e3323166e1f0e8ba79e19e217b2c4a53c970d4cca0cfb1078979df"
+	tests := []struct {
+		name       string
+		enabled    bool
+		privateKey string
+		wantErr    error
+	}{
+		{name: "enforced wallet", enabled: true},
+		{name: "enforced raw key", enabled: true, privateKey: key, wantErr: derrors.Forbidden},
+		{name: "allowed wallet", enabled: false},
+		{name: "allowed raw key", enabled: false, privateKey: key},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(v bool) { eKeyEnabled = v }(eKeyEnabled)
+			eKeyEnabled = tt.enabled
+			if err := conformKey(tt.privateKey); !errors.Is(err, tt.wantErr) {
+				t.Errorf("conformKey() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -5,16 +5,47 @@ import (
 	"justlend/internal"
 	"justlend/internal/derrors"
 	"justlend/internal/protos/core"
+	"time"
 )
 
 type RentResourceMeta struct {
-	Receive    string            `json:"receive"`
-	Type       core.ResourceCode `json:"type"`
-	Amount     int64             `json:"amount"`
-	PrivateKey string            `json:"privateKey"`
+	Receive string            `json:"receive"`
+	Type    core.ResourceCode `json:"type"`
+	Amount  int64             `json:"amount"`
+	// Wallet is the ID or address of the keystore wallet that pays for
+	// the transaction, it takes precedence over the PrivateKey.
+	Wallet string `json:"wallet"`
+	// PrivateKey is the raw hex private key of the payer, only accepted
+	// if the encrypted keys are not enforced.
+	PrivateKey string `json:"privateKey"`
+	// Duration is the optional rental duration in seconds, the rental is
+	// returned automatically once it elapses. Only the wallets of the
+	// signer are supported, since the raw private keys are never stored.
+	Duration int64 `json:"duration"`
+	// Prepay is the period in seconds of the rent prepaid by the rental,
+	// DefaultPrepay is used if it's zero.
+	Prepay int64 `json:"prepay"`
+	// Wait blocks the request until the transaction is final.
+	Wait bool `json:"-"`
+	// DryRun simulates the call of the Owner & builds the unsigned
+	// transaction, nothing is signed, broadcast nor recorded.
+	DryRun bool `json:"-"`
+	// Owner is the base58 address of the payer of an Unsigned transaction or
+	// a dry run, whose key is kept offline.
+	Owner string `json:"owner"`
+	// Unsigned builds the transaction of the Owner to be signed offline, the
+	// order is recorded & moves along once the transaction is broadcast.
+	Unsigned bool `json:"-"`
 }
 
-func (m *RentResourceMeta) Conform(_ context.Context) error {
+// keyless reports whether the transaction is built for the Owner without
+// any key, i.e. the Unsigned transactions & the dry runs.
+func (m *RentResourceMeta) keyless() bool { return m.Unsigned || m.DryRun }
+
+func (m *RentResourceMeta) Conform(ctx context.Context) error {
+	if err := conformKey(m.PrivateKey); err != nil {
+		return err
+	}
 	switch {
 	case !internal.IsValidAddress(m.Receive):
 		return derrors.InvalidParam
@@ -22,16 +53,37 @@ func (m *RentResourceMeta) Conform(_ context.Context) error {
 		return derrors.InvalidParam
 	case m.Amount <= 0:
 		return derrors.InvalidParam
-	case len(m.PrivateKey) != 64:
+	case m.keyless() && (!internal.IsValidAddress(m.Owner) || !internal.IsEmpty(m.Wallet) || !internal.IsEmpty(m.PrivateKey)):
+		return derrors.InvalidParam
+	case !m.keyless() && internal.IsEmpty(m.Wallet) && len(m.PrivateKey) != 64:
 		return derrors.InvalidParam
-	default:
-		return nil
+	case m.Duration < 0 || (m.Duration > 0 && internal.IsEmpty(m.Wallet)):
+		return derrors.InvalidParam
+	}
+	wallet := m.Wallet
+	if m.keyless() {
+		wallet = m.Owner
+	}
+	if err := conformAccess(ctx, wallet, m.Receive); err != nil {
+		return err
 	}
+	return conformPrepay(&m.Prepay)
 }
 
 type RentResourceRL struct {
+	// OrderId is the ID of the order recorded in the ledger.
+	OrderId     string `json:"orderId"`
 	TxId        string `json:"txId"`
 	StakePerTrx int64  `json:"stakePerTrx"`
+	// Receipt is the final receipt of the transaction if waited.
+	Receipt *TransactionRL `json:"receipt,omitempty"`
+	// ReturnAt is the time the rental is returned if a duration is given.
+	ReturnAt *time.Time `json:"returnAt,omitempty"`
+	// DryRun is the simulation of a dry run, no order is recorded then.
+	DryRun *DryRunRL `json:"dryRun,omitempty"`
+	// Unsigned is the transaction built to be signed offline, which is sent
+	// to the broadcast once signed.
+	Unsigned *DryRunRL `json:"unsigned,omitempty"`
 }
 
 var (
@@ -0,0 +1,54 @@
+package justlend
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/core"
+)
+
+type RentalMeta struct {
+	Renter   string
+	Receiver string
+	// Type is the optional resource type, both resources are queried if nil.
+	Type *core.ResourceCode
+}
+
+func (m *RentalMeta) Conform(_ context.Context) error {
+	switch {
+	case !internal.IsValidAddress(m.Renter):
+		return derrors.InvalidParam
+	case !internal.IsValidAddress(m.Receiver):
+		return derrors.InvalidParam
+	case m.Type != nil && !internal.Contains(*m.Type, core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY):
+		return derrors.InvalidParam
+	default:
+		return nil
+	}
+}
+
+type RentalRL struct {
+	Renter   string            `json:"renter"`
+	Receiver string            `json:"receiver"`
+	Type     core.ResourceCode `json:"type"`
+	// StakePerTrx is the staked TRX amount in SUN of the rented resource,
+	// which is the amount to return.
+	StakePerTrx        int64           `json:"stakePerTrx"`
+	SecurityDeposit    decimal.Decimal `json:"securityDeposit"`
+	AccruedFee         decimal.Decimal `json:"accruedFee"`
+	RemainingDeposit   decimal.Decimal `json:"remainingDeposit"`
+	LiquidateThreshold decimal.Decimal `json:"liquidateThreshold"`
+	// Liquidatable reports whether the remaining deposit falls below the
+	// liquidation threshold.
+	Liquidatable bool `json:"liquidatable"`
+}
+
+var (
+	_ internal.Conformer = (*RentalMeta)(nil)
+)
+
+type RentalService interface {
+	// Rentals returns the active rentals from the renter to the receiver.
+	Rentals(ctx context.Context, req *RentalMeta) ([]*RentalRL, error)
+}
@@ -8,29 +8,69 @@ import (
 )
 
 type ReturnResourceMeta struct {
-	Receive     string            `json:"receive"`
-	Type        core.ResourceCode `json:"type"`
-	StakePerTrx int64             `json:"stakePerTrx"`
-	PrivateKey  string            `json:"privateKey"`
+	Receive string            `json:"receive"`
+	Type    core.ResourceCode `json:"type"`
+	// StakePerTrx is the staked TRX amount in SUN to return, the full
+	// outstanding amount of the rental is returned if it's zero.
+	StakePerTrx int64 `json:"stakePerTrx"`
+	// Wallet is the ID or address of the keystore wallet that pays for
+	// the transaction, it takes precedence over the PrivateKey.
+	Wallet string `json:"wallet"`
+	// PrivateKey is the raw hex private key of the payer, only accepted
+	// if the encrypted keys are not enforced.
+	PrivateKey string `json:"privateKey"`
+	// Wait blocks the request until the transaction is final.
+	Wait bool `json:"-"`
+	// DryRun simulates the call of the Owner & builds the unsigned
+	// transaction, nothing is signed, broadcast nor recorded.
+	DryRun bool `json:"-"`
+	// Owner is the base58 address of the payer of an Unsigned transaction or
+	// a dry run, whose key is kept offline.
+	Owner string `json:"owner"`
+	// Unsigned builds the transaction of the Owner to be signed offline, the
+	// order is recorded & moves along once the transaction is broadcast.
+	Unsigned bool `json:"-"`
 }
 
-func (m *ReturnResourceMeta) Conform(_ context.Context) error {
+// keyless reports whether the transaction is built for the Owner without
+// any key, i.e. the Unsigned transactions & the dry runs.
+func (m *ReturnResourceMeta) keyless() bool { return m.Unsigned || m.DryRun }
+
+func (m *ReturnResourceMeta) Conform(ctx context.Context) error {
+	if err := conformKey(m.PrivateKey); err != nil {
+		return err
+	}
 	switch {
 	case !internal.IsValidAddress(m.Receive):
 		return derrors.InvalidParam
 	case !internal.Contains(m.Type, core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY):
 		return derrors.InvalidParam
-	case m.StakePerTrx <= 0:
+	case m.StakePerTrx < 0:
 		return derrors.InvalidParam
-	case len(m.PrivateKey) != 64:
+	case m.keyless() && (!internal.IsValidAddress(m.Owner) || !internal.IsEmpty(m.Wallet) || !internal.IsEmpty(m.PrivateKey)):
 		return derrors.InvalidParam
-	default:
-		return nil
+	case !m.keyless() && internal.IsEmpty(m.Wallet) && len(m.PrivateKey) != 64:
+		return derrors.InvalidParam
+	}
+	wallet := m.Wallet
+	if m.keyless() {
+		wallet = m.Owner
 	}
+	return conformAccess(ctx, wallet, m.Receive)
 }
 
 type ReturnResourceRL struct {
-	TxId string `json:"txId"`
+	// OrderId is the ID of the order recorded in the ledger.
+	OrderId     string `json:"orderId"`
+	TxId        string `json:"txId"`
+	StakePerTrx int64  `json:"stakePerTrx"`
+	// Receipt is the final receipt of the transaction if waited.
+	Receipt *TransactionRL `json:"receipt,omitempty"`
+	// DryRun is the simulation of a dry run, no order is recorded then.
+	DryRun *DryRunRL `json:"dryRun,omitempty"`
+	// Unsigned is the transaction built to be signed offline, which is sent
+	// to the broadcast once signed.
+	Unsigned *DryRunRL `json:"unsigned,omitempty"`
 }
 
 var (
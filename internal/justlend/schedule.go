@@ -0,0 +1,93 @@
+package justlend
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/core"
+	"time"
+)
+
+// ScheduleStatus is the status of a scheduled return.
+type ScheduleStatus string
+
+const (
+	// SchedulePending means the rental is not returned yet, including
+	// the returns being retried.
+	SchedulePending   ScheduleStatus = "pending"
+	ScheduleDone      ScheduleStatus = "done"
+	ScheduleFailed    ScheduleStatus = "failed"
+	ScheduleCancelled ScheduleStatus = "cancelled"
+)
+
+// Valid reports whether s is a known status.
+func (s ScheduleStatus) Valid() bool {
+	return internal.Contains(s, SchedulePending, ScheduleDone, ScheduleFailed, ScheduleCancelled)
+}
+
+// ScheduleRL is the scheduled return of a rent order.
+type ScheduleRL struct {
+	// OrderId is the ID of the rent order to return.
+	OrderId  string            `json:"orderId"`
+	Wallet   string            `json:"wallet"`
+	Receiver string            `json:"receiver"`
+	Type     core.ResourceCode `json:"type"`
+	Status   ScheduleStatus    `json:"status"`
+	ReturnAt time.Time         `json:"returnAt"`
+	// Attempts counts the failed attempts, the next attempt is made at
+	// NextAttemptAt.
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	// ReturnOrderId is the ID of the last return order.
+	ReturnOrderId string    `json:"returnOrderId,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type SchedulesMeta struct {
+	Status ScheduleStatus
+	// Offset & Limit select the page of the schedules, the earliest
+	// returns come first.
+	Offset, Limit uint64
+}
+
+func (m *SchedulesMeta) Conform(_ context.Context) error {
+	switch {
+	case m.Status != "" && !m.Status.Valid():
+		return derrors.InvalidParam
+	case m.Limit > maxOrderLimit:
+		return derrors.InvalidParam
+	}
+	if m.Limit == 0 {
+		m.Limit = 20
+	}
+	return nil
+}
+
+type ScheduleMeta struct {
+	OrderId string
+}
+
+func (m *ScheduleMeta) Conform(_ context.Context) error {
+	if _, err := uuid.Parse(m.OrderId); err != nil {
+		return derrors.InvalidParam
+	}
+	return nil
+}
+
+var (
+	_ internal.Conformer = (*SchedulesMeta)(nil)
+	_ internal.Conformer = (*ScheduleMeta)(nil)
+)
+
+type ScheduleService interface {
+	// Schedules returns the page of the scheduled returns along with the
+	// total count of them.
+	Schedules(ctx context.Context, req *SchedulesMeta) ([]*ScheduleRL, int, error)
+	// CancelSchedule cancels the pending return, the rental is kept.
+	CancelSchedule(ctx context.Context, req *ScheduleMeta) (*ScheduleRL, error)
+	// ReturnDue returns the rentals whose scheduled returns are due.
+	ReturnDue(ctx context.Context) error
+}
@@ -0,0 +1,66 @@
+package justlend
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"time"
+)
+
+type TransactionMeta struct {
+	TxId string
+}
+
+func (m *TransactionMeta) Conform(_ context.Context) error {
+	if b, err := hex.DecodeString(m.TxId); err != nil || len(b) != 32 {
+		return derrors.InvalidParam
+	}
+	return nil
+}
+
+type TransactionRL struct {
+	TxId           string `json:"txId"`
+	Status         string `json:"status"` // One of pending, confirmed, reverted & expired.
+	BlockNumber    int64  `json:"blockNumber,omitempty"`
+	BlockTimestamp int64  `json:"blockTimestamp,omitempty"`
+	Fee            int64  `json:"fee"` // Total fee burnt in SUN.
+	EnergyUsed     int64  `json:"energyUsed"`
+	EnergyFee      int64  `json:"energyFee"`
+	NetUsed        int64  `json:"netUsed"`
+	NetFee         int64  `json:"netFee"`
+	ContractResult string `json:"contractResult,omitempty"`
+	ContractOutput string `json:"contractOutput,omitempty"`
+	RevertReason   string `json:"revertReason,omitempty"`
+}
+
+// DryRunRL is the simulation of a contract call along with its transaction,
+// which is built but neither signed nor broadcast.
+type DryRunRL struct {
+	Owner string `json:"owner"`
+	// ContractResult is SUCCESS unless the call would fail, i.e. REVERT.
+	ContractResult string `json:"contractResult"`
+	RevertReason   string `json:"revertReason,omitempty"`
+	EnergyUsed     int64  `json:"energyUsed"`
+	EnergyPenalty  int64  `json:"energyPenalty"`
+	// FeeLimit & CallValue are in SUN.
+	FeeLimit  int64 `json:"feeLimit"`
+	CallValue int64 `json:"callValue"`
+	// TxId & RawData are the txID & the hex encoded raw data of the unsigned
+	// transaction, which must be signed before the Expiration. RawDataJSON
+	// is the raw data decoded for review.
+	TxId        string          `json:"txId"`
+	RawData     string          `json:"rawData"`
+	RawDataJSON json.RawMessage `json:"rawDataJson"`
+	Expiration  time.Time       `json:"expiration"`
+}
+
+var (
+	_ internal.Conformer = (*TransactionMeta)(nil)
+)
+
+type TransactionService interface {
+	// Transaction returns the current status & receipt of the transaction.
+	Transaction(ctx context.Context, req *TransactionMeta) (*TransactionRL, error)
+}
@@ -0,0 +1,95 @@
+package justlend
+
+import (
+	"context"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"math/big"
+)
+
+// TransferMeta transfers a TRC20 token with just-in-time energy, the energy
+// the sender lacks for the transfer is rented right before it.
+type TransferMeta struct {
+	// Token is the base58 address of the TRC20 contract, i.e. USDT.
+	Token string `json:"token"`
+	To    string `json:"to"`
+	// Amount is the decimal amount of the token in its smallest unit.
+	Amount string `json:"amount"`
+	// Wallet is the ID or address of the keystore wallet of the sender, it
+	// takes precedence over the PrivateKey.
+	Wallet string `json:"wallet"`
+	// PrivateKey is the raw hex private key of the sender, only accepted if
+	// the encrypted keys are not enforced.
+	PrivateKey string `json:"privateKey"`
+	// Return returns the rented energy once the transfer is final.
+	Return bool `json:"return"`
+
+	// Value is the parsed Amount.
+	Value *big.Int `json:"-"`
+}
+
+func (m *TransferMeta) Conform(ctx context.Context) error {
+	if err := conformKey(m.PrivateKey); err != nil {
+		return err
+	}
+	switch {
+	case !internal.IsValidAddress(m.Token):
+		return derrors.InvalidParam
+	case !internal.IsValidAddress(m.To):
+		return derrors.InvalidParam
+	case internal.IsEmpty(m.Wallet) && len(m.PrivateKey) != 64:
+		return derrors.InvalidParam
+	}
+	if err := conformAccess(ctx, m.Wallet, ""); err != nil {
+		return err
+	}
+	v, ok := new(big.Int).SetString(m.Amount, 10)
+	if !ok || v.Sign() <= 0 {
+		return derrors.InvalidParam
+	}
+	m.Value = v
+	return nil
+}
+
+type TransferRL struct {
+	// Energy is the estimated energy of the transfer & Available is the
+	// energy the sender had before it, the shortfall is Rented.
+	Energy    int64 `json:"energy"`
+	Available int64 `json:"available"`
+	Rented    int64 `json:"rented"`
+
+	// The orders & the transactions of the rent & the return if any.
+	RentOrderId   string `json:"rentOrderId,omitempty"`
+	RentTxId      string `json:"rentTxId,omitempty"`
+	ReturnOrderId string `json:"returnOrderId,omitempty"`
+	ReturnTxId    string `json:"returnTxId,omitempty"`
+	// ReturnError is the reason the rented energy was not returned, the
+	// transfer is already done then.
+	ReturnError string `json:"returnError,omitempty"`
+
+	TxId string `json:"txId"`
+	// Receipt is the final receipt of the transfer.
+	Receipt *TransactionRL `json:"receipt"`
+
+	// The costs in SUN. RentFee is the prepaid fee of the rental, Refund is
+	// the estimated deposit refunded by the return, Fees is the TRX burnt
+	// by all the transactions.
+	RentFee int64 `json:"rentFee"`
+	Refund  int64 `json:"refund"`
+	Fees    int64 `json:"fees"`
+	// TotalCost is RentFee - Refund + Fees, BurnCost is what the transfer
+	// costs if the shortfall is burnt at the EnergyPrice instead.
+	TotalCost   int64 `json:"totalCost"`
+	BurnCost    int64 `json:"burnCost"`
+	EnergyPrice int64 `json:"energyPrice"`
+}
+
+var (
+	_ internal.Conformer = (*TransferMeta)(nil)
+)
+
+type TransferService interface {
+	// Transfer transfers the TRC20 token, the energy the sender lacks is
+	// rented first & optionally returned afterward.
+	Transfer(ctx context.Context, req *TransferMeta) (*TransferRL, error)
+}
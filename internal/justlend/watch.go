@@ -0,0 +1,132 @@
+package justlend
+
+import (
+	"context"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/core"
+	"net/url"
+	"time"
+)
+
+// WatchAction is what the watchdog does once the security deposit of a
+// rental runs low.
+type WatchAction string
+
+const (
+	// WatchAlert posts an alert to the webhook.
+	WatchAlert WatchAction = "alert"
+	// WatchTopUp tops up the security deposit from the wallet.
+	WatchTopUp WatchAction = "topup"
+	// WatchNone disables the watchdog of the rental.
+	WatchNone WatchAction = "none"
+)
+
+// WatchMeta identifies the watched rental.
+type WatchMeta struct {
+	Renter   string            `json:"renter"`
+	Receiver string            `json:"receiver"`
+	Type     core.ResourceCode `json:"type"`
+}
+
+func (m *WatchMeta) Conform(_ context.Context) error {
+	switch {
+	case !internal.IsValidAddress(m.Renter):
+		return derrors.InvalidParam
+	case !internal.IsValidAddress(m.Receiver):
+		return derrors.InvalidParam
+	case !internal.Contains(m.Type, core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY):
+		return derrors.InvalidParam
+	default:
+		return nil
+	}
+}
+
+// WatchPolicyMeta is the policy of the watchdog of a rental, the rentals
+// without a policy are alerted to the default webhook.
+type WatchPolicyMeta struct {
+	WatchMeta
+	Action WatchAction `json:"action"`
+	// Margin is the deposit in SUN kept above the liquidation threshold,
+	// the action is taken once the remaining deposit falls below both.
+	Margin int64 `json:"margin"`
+	// Wallet & TopUp are the wallet of the signer & the amount in SUN
+	// added to the deposit by a top-up.
+	Wallet string `json:"wallet"`
+	TopUp  int64  `json:"topUp"`
+	// Webhook is the URL the alerts are posted to, the default webhook
+	// is used if it's empty.
+	Webhook string `json:"webhook"`
+}
+
+func (m *WatchPolicyMeta) Conform(ctx context.Context) error {
+	if err := m.WatchMeta.Conform(ctx); err != nil {
+		return err
+	}
+	switch {
+	case !internal.Contains(m.Action, WatchAlert, WatchTopUp, WatchNone):
+		return derrors.InvalidParam
+	case m.Margin < 0:
+		return derrors.InvalidParam
+	case m.Action == WatchTopUp && (internal.IsEmpty(m.Wallet) || m.TopUp <= 0):
+		return derrors.InvalidParam
+	case !internal.IsEmpty(m.Webhook) && !isWebhook(m.Webhook):
+		return derrors.InvalidParam
+	case m.Action == WatchTopUp:
+		return conformAccess(ctx, m.Wallet, m.Receiver)
+	default:
+		return nil
+	}
+}
+
+func isWebhook(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && internal.Contains(u.Scheme, "http", "https") && u.Host != ""
+}
+
+type WatchPolicyRL struct {
+	Renter   string            `json:"renter"`
+	Receiver string            `json:"receiver"`
+	Type     core.ResourceCode `json:"type"`
+	Action   WatchAction       `json:"action"`
+	Margin   int64             `json:"margin"`
+	Wallet   string            `json:"wallet,omitempty"`
+	TopUp    int64             `json:"topUp,omitempty"`
+	Webhook  string            `json:"webhook,omitempty"`
+	// ActedAt is the time of the last alert or top-up.
+	ActedAt   *time.Time `json:"actedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type WatchesMeta struct {
+	// Offset & Limit select the page of the policies.
+	Offset, Limit uint64
+}
+
+func (m *WatchesMeta) Conform(_ context.Context) error {
+	if m.Limit > maxOrderLimit {
+		return derrors.InvalidParam
+	} else if m.Limit == 0 {
+		m.Limit = 20
+	}
+	return nil
+}
+
+var (
+	_ internal.Conformer = (*WatchMeta)(nil)
+	_ internal.Conformer = (*WatchPolicyMeta)(nil)
+	_ internal.Conformer = (*WatchesMeta)(nil)
+)
+
+type WatchService interface {
+	// SetWatch creates or replaces the watchdog policy of the rental.
+	SetWatch(ctx context.Context, req *WatchPolicyMeta) (*WatchPolicyRL, error)
+	// Watches returns the page of the policies along with the total count.
+	Watches(ctx context.Context, req *WatchesMeta) ([]*WatchPolicyRL, int, error)
+	// DeleteWatch deletes the policy, the default policy applies then.
+	DeleteWatch(ctx context.Context, req *WatchMeta) error
+	// Watch checks the deposits of the tracked rentals once and acts on
+	// the ones running low.
+	Watch(ctx context.Context) error
+}
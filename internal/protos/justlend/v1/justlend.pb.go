@@ -0,0 +1,1293 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: justlend/v1/justlend.proto
+
+package justlendv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ResourceType is the rented resource, its values are the ones of the Tron
+// protocol.
+type ResourceType int32
+
+const (
+	ResourceType_BANDWIDTH ResourceType = 0
+	ResourceType_ENERGY    ResourceType = 1
+)
+
+// Enum value maps for ResourceType.
+var (
+	ResourceType_name = map[int32]string{
+		0: "BANDWIDTH",
+		1: "ENERGY",
+	}
+	ResourceType_value = map[string]int32{
+		"BANDWIDTH": 0,
+		"ENERGY":    1,
+	}
+)
+
+func (x ResourceType) Enum() *ResourceType {
+	p := new(ResourceType)
+	*p = x
+	return p
+}
+
+func (x ResourceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResourceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_justlend_v1_justlend_proto_enumTypes[0].Descriptor()
+}
+
+func (ResourceType) Type() protoreflect.EnumType {
+	return &file_justlend_v1_justlend_proto_enumTypes[0]
+}
+
+func (x ResourceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResourceType.Descriptor instead.
+func (ResourceType) EnumDescriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{0}
+}
+
+type QuoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Amount is the energy or the bandwidth to rent.
+	Amount int64        `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type   ResourceType `protobuf:"varint,2,opt,name=type,proto3,enum=justlend.v1.ResourceType" json:"type,omitempty"`
+	// Owner is the optional address of the renter.
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Prepay is the period in seconds of the prepaid rent, two days if zero.
+	Prepay int64 `protobuf:"varint,4,opt,name=prepay,proto3" json:"prepay,omitempty"`
+	// Partial quotes with the last known contract parameters if they can't be
+	// read, which are listed in the estimated of the quote.
+	Partial bool `protobuf:"varint,5,opt,name=partial,proto3" json:"partial,omitempty"`
+}
+
+func (x *QuoteRequest) Reset() {
+	*x = QuoteRequest{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteRequest) ProtoMessage() {}
+
+func (x *QuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteRequest.ProtoReflect.Descriptor instead.
+func (*QuoteRequest) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *QuoteRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetType() ResourceType {
+	if x != nil {
+		return x.Type
+	}
+	return ResourceType_BANDWIDTH
+}
+
+func (x *QuoteRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetPrepay() int64 {
+	if x != nil {
+		return x.Prepay
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+// Quote is the fee of renting the resource, the amounts are in TRX.
+type Quote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// OrderId is the ID of the quote recorded in the ledger.
+	OrderId    string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	RentAmount int64  `protobuf:"varint,2,opt,name=rent_amount,json=rentAmount,proto3" json:"rent_amount,omitempty"`
+	// StakePerTrx is the TRX staked for the rented resource.
+	StakePerTrx        int64  `protobuf:"varint,3,opt,name=stake_per_trx,json=stakePerTrx,proto3" json:"stake_per_trx,omitempty"`
+	LiquidateThreshold string `protobuf:"bytes,4,opt,name=liquidate_threshold,json=liquidateThreshold,proto3" json:"liquidate_threshold,omitempty"`
+	// RentalRate is the rent per second of a staked TRX.
+	RentalRate  string `protobuf:"bytes,5,opt,name=rental_rate,json=rentalRate,proto3" json:"rental_rate,omitempty"`
+	FeeRatio    string `protobuf:"bytes,6,opt,name=fee_ratio,json=feeRatio,proto3" json:"fee_ratio,omitempty"`
+	MinFee      string `protobuf:"bytes,7,opt,name=min_fee,json=minFee,proto3" json:"min_fee,omitempty"`
+	CurFeeRatio string `protobuf:"bytes,8,opt,name=cur_fee_ratio,json=curFeeRatio,proto3" json:"cur_fee_ratio,omitempty"`
+	RentFee     string `protobuf:"bytes,9,opt,name=rent_fee,json=rentFee,proto3" json:"rent_fee,omitempty"`
+	// PrePayFee is the call value of the rental.
+	PrePayFee string `protobuf:"bytes,10,opt,name=pre_pay_fee,json=prePayFee,proto3" json:"pre_pay_fee,omitempty"`
+	// Prepay is the prepaid period in seconds of the rent fee.
+	Prepay     int64  `protobuf:"varint,11,opt,name=prepay,proto3" json:"prepay,omitempty"`
+	HourlyRent string `protobuf:"bytes,12,opt,name=hourly_rent,json=hourlyRent,proto3" json:"hourly_rent,omitempty"`
+	DailyRent  string `protobuf:"bytes,13,opt,name=daily_rent,json=dailyRent,proto3" json:"daily_rent,omitempty"`
+	// Estimated lists the contract parameters of a partial quote which were not
+	// read, the quote is exact if it's empty.
+	Estimated []string `protobuf:"bytes,14,rep,name=estimated,proto3" json:"estimated,omitempty"`
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Quote) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Quote) GetRentAmount() int64 {
+	if x != nil {
+		return x.RentAmount
+	}
+	return 0
+}
+
+func (x *Quote) GetStakePerTrx() int64 {
+	if x != nil {
+		return x.StakePerTrx
+	}
+	return 0
+}
+
+func (x *Quote) GetLiquidateThreshold() string {
+	if x != nil {
+		return x.LiquidateThreshold
+	}
+	return ""
+}
+
+func (x *Quote) GetRentalRate() string {
+	if x != nil {
+		return x.RentalRate
+	}
+	return ""
+}
+
+func (x *Quote) GetFeeRatio() string {
+	if x != nil {
+		return x.FeeRatio
+	}
+	return ""
+}
+
+func (x *Quote) GetMinFee() string {
+	if x != nil {
+		return x.MinFee
+	}
+	return ""
+}
+
+func (x *Quote) GetCurFeeRatio() string {
+	if x != nil {
+		return x.CurFeeRatio
+	}
+	return ""
+}
+
+func (x *Quote) GetRentFee() string {
+	if x != nil {
+		return x.RentFee
+	}
+	return ""
+}
+
+func (x *Quote) GetPrePayFee() string {
+	if x != nil {
+		return x.PrePayFee
+	}
+	return ""
+}
+
+func (x *Quote) GetPrepay() int64 {
+	if x != nil {
+		return x.Prepay
+	}
+	return 0
+}
+
+func (x *Quote) GetHourlyRent() string {
+	if x != nil {
+		return x.HourlyRent
+	}
+	return ""
+}
+
+func (x *Quote) GetDailyRent() string {
+	if x != nil {
+		return x.DailyRent
+	}
+	return ""
+}
+
+func (x *Quote) GetEstimated() []string {
+	if x != nil {
+		return x.Estimated
+	}
+	return nil
+}
+
+// Receipt is the final receipt of a transaction, the fees are in SUN.
+type Receipt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// Status is one of pending, confirmed, reverted & expired.
+	Status         string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	BlockNumber    int64  `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockTimestamp int64  `protobuf:"varint,4,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	Fee            int64  `protobuf:"varint,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	EnergyUsed     int64  `protobuf:"varint,6,opt,name=energy_used,json=energyUsed,proto3" json:"energy_used,omitempty"`
+	EnergyFee      int64  `protobuf:"varint,7,opt,name=energy_fee,json=energyFee,proto3" json:"energy_fee,omitempty"`
+	NetUsed        int64  `protobuf:"varint,8,opt,name=net_used,json=netUsed,proto3" json:"net_used,omitempty"`
+	NetFee         int64  `protobuf:"varint,9,opt,name=net_fee,json=netFee,proto3" json:"net_fee,omitempty"`
+	ContractResult string `protobuf:"bytes,10,opt,name=contract_result,json=contractResult,proto3" json:"contract_result,omitempty"`
+	ContractOutput string `protobuf:"bytes,11,opt,name=contract_output,json=contractOutput,proto3" json:"contract_output,omitempty"`
+	RevertReason   string `protobuf:"bytes,12,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Receipt) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *Receipt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Receipt) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Receipt) GetBlockTimestamp() int64 {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return 0
+}
+
+func (x *Receipt) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Receipt) GetEnergyUsed() int64 {
+	if x != nil {
+		return x.EnergyUsed
+	}
+	return 0
+}
+
+func (x *Receipt) GetEnergyFee() int64 {
+	if x != nil {
+		return x.EnergyFee
+	}
+	return 0
+}
+
+func (x *Receipt) GetNetUsed() int64 {
+	if x != nil {
+		return x.NetUsed
+	}
+	return 0
+}
+
+func (x *Receipt) GetNetFee() int64 {
+	if x != nil {
+		return x.NetFee
+	}
+	return 0
+}
+
+func (x *Receipt) GetContractResult() string {
+	if x != nil {
+		return x.ContractResult
+	}
+	return ""
+}
+
+func (x *Receipt) GetContractOutput() string {
+	if x != nil {
+		return x.ContractOutput
+	}
+	return ""
+}
+
+func (x *Receipt) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type RentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Receiver string       `protobuf:"bytes,1,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Type     ResourceType `protobuf:"varint,2,opt,name=type,proto3,enum=justlend.v1.ResourceType" json:"type,omitempty"`
+	Amount   int64        `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Wallet is the ID or the address of the wallet of the signer paying for
+	// the rental, it takes precedence over the private key.
+	Wallet     string `protobuf:"bytes,4,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	PrivateKey string `protobuf:"bytes,5,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// Duration is the optional rental duration in seconds, the rental is
+	// returned once it elapses.
+	Duration int64 `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	Prepay   int64 `protobuf:"varint,7,opt,name=prepay,proto3" json:"prepay,omitempty"`
+	// Wait blocks the call until the transaction is final.
+	Wait bool `protobuf:"varint,8,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (x *RentRequest) Reset() {
+	*x = RentRequest{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RentRequest) ProtoMessage() {}
+
+func (x *RentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RentRequest.ProtoReflect.Descriptor instead.
+func (*RentRequest) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RentRequest) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *RentRequest) GetType() ResourceType {
+	if x != nil {
+		return x.Type
+	}
+	return ResourceType_BANDWIDTH
+}
+
+func (x *RentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RentRequest) GetWallet() string {
+	if x != nil {
+		return x.Wallet
+	}
+	return ""
+}
+
+func (x *RentRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *RentRequest) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *RentRequest) GetPrepay() int64 {
+	if x != nil {
+		return x.Prepay
+	}
+	return 0
+}
+
+func (x *RentRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+type RentReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TxId    string `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// StakePerTrx is the staked amount in SUN, which is the amount to return.
+	StakePerTrx int64 `protobuf:"varint,3,opt,name=stake_per_trx,json=stakePerTrx,proto3" json:"stake_per_trx,omitempty"`
+	// Receipt is set if the call waited.
+	Receipt *Receipt `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// ReturnAt is the unix time the rental is returned if a duration is given.
+	ReturnAt int64 `protobuf:"varint,5,opt,name=return_at,json=returnAt,proto3" json:"return_at,omitempty"`
+}
+
+func (x *RentReply) Reset() {
+	*x = RentReply{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RentReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RentReply) ProtoMessage() {}
+
+func (x *RentReply) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RentReply.ProtoReflect.Descriptor instead.
+func (*RentReply) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RentReply) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RentReply) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *RentReply) GetStakePerTrx() int64 {
+	if x != nil {
+		return x.StakePerTrx
+	}
+	return 0
+}
+
+func (x *RentReply) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *RentReply) GetReturnAt() int64 {
+	if x != nil {
+		return x.ReturnAt
+	}
+	return 0
+}
+
+type ReturnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Receiver string       `protobuf:"bytes,1,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Type     ResourceType `protobuf:"varint,2,opt,name=type,proto3,enum=justlend.v1.ResourceType" json:"type,omitempty"`
+	// StakePerTrx is the staked amount in SUN to return, the whole rental is
+	// returned if it's zero.
+	StakePerTrx int64  `protobuf:"varint,3,opt,name=stake_per_trx,json=stakePerTrx,proto3" json:"stake_per_trx,omitempty"`
+	Wallet      string `protobuf:"bytes,4,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	PrivateKey  string `protobuf:"bytes,5,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Wait        bool   `protobuf:"varint,6,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (x *ReturnRequest) Reset() {
+	*x = ReturnRequest{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnRequest) ProtoMessage() {}
+
+func (x *ReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReturnRequest) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReturnRequest) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *ReturnRequest) GetType() ResourceType {
+	if x != nil {
+		return x.Type
+	}
+	return ResourceType_BANDWIDTH
+}
+
+func (x *ReturnRequest) GetStakePerTrx() int64 {
+	if x != nil {
+		return x.StakePerTrx
+	}
+	return 0
+}
+
+func (x *ReturnRequest) GetWallet() string {
+	if x != nil {
+		return x.Wallet
+	}
+	return ""
+}
+
+func (x *ReturnRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *ReturnRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+type ReturnReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId     string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TxId        string   `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	StakePerTrx int64    `protobuf:"varint,3,opt,name=stake_per_trx,json=stakePerTrx,proto3" json:"stake_per_trx,omitempty"`
+	Receipt     *Receipt `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
+}
+
+func (x *ReturnReply) Reset() {
+	*x = ReturnReply{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnReply) ProtoMessage() {}
+
+func (x *ReturnReply) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnReply.ProtoReflect.Descriptor instead.
+func (*ReturnReply) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReturnReply) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReturnReply) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *ReturnReply) GetStakePerTrx() int64 {
+	if x != nil {
+		return x.StakePerTrx
+	}
+	return 0
+}
+
+func (x *ReturnReply) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+type GetRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Renter   string       `protobuf:"bytes,1,opt,name=renter,proto3" json:"renter,omitempty"`
+	Receiver string       `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Type     ResourceType `protobuf:"varint,3,opt,name=type,proto3,enum=justlend.v1.ResourceType" json:"type,omitempty"`
+}
+
+func (x *GetRentalRequest) Reset() {
+	*x = GetRentalRequest{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRentalRequest) ProtoMessage() {}
+
+func (x *GetRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRentalRequest.ProtoReflect.Descriptor instead.
+func (*GetRentalRequest) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRentalRequest) GetRenter() string {
+	if x != nil {
+		return x.Renter
+	}
+	return ""
+}
+
+func (x *GetRentalRequest) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *GetRentalRequest) GetType() ResourceType {
+	if x != nil {
+		return x.Type
+	}
+	return ResourceType_BANDWIDTH
+}
+
+type WatchRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Renter   string       `protobuf:"bytes,1,opt,name=renter,proto3" json:"renter,omitempty"`
+	Receiver string       `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Type     ResourceType `protobuf:"varint,3,opt,name=type,proto3,enum=justlend.v1.ResourceType" json:"type,omitempty"`
+	// Interval is the seconds between two checks of the rental, ten if zero.
+	Interval int64 `protobuf:"varint,4,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *WatchRentalRequest) Reset() {
+	*x = WatchRentalRequest{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRentalRequest) ProtoMessage() {}
+
+func (x *WatchRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRentalRequest.ProtoReflect.Descriptor instead.
+func (*WatchRentalRequest) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchRentalRequest) GetRenter() string {
+	if x != nil {
+		return x.Renter
+	}
+	return ""
+}
+
+func (x *WatchRentalRequest) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *WatchRentalRequest) GetType() ResourceType {
+	if x != nil {
+		return x.Type
+	}
+	return ResourceType_BANDWIDTH
+}
+
+func (x *WatchRentalRequest) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+// Rental is an active rental, the deposits & the fees are in TRX.
+type Rental struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Renter   string       `protobuf:"bytes,1,opt,name=renter,proto3" json:"renter,omitempty"`
+	Receiver string       `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Type     ResourceType `protobuf:"varint,3,opt,name=type,proto3,enum=justlend.v1.ResourceType" json:"type,omitempty"`
+	// StakePerTrx is the staked amount in SUN of the rented resource.
+	StakePerTrx        int64  `protobuf:"varint,4,opt,name=stake_per_trx,json=stakePerTrx,proto3" json:"stake_per_trx,omitempty"`
+	SecurityDeposit    string `protobuf:"bytes,5,opt,name=security_deposit,json=securityDeposit,proto3" json:"security_deposit,omitempty"`
+	AccruedFee         string `protobuf:"bytes,6,opt,name=accrued_fee,json=accruedFee,proto3" json:"accrued_fee,omitempty"`
+	RemainingDeposit   string `protobuf:"bytes,7,opt,name=remaining_deposit,json=remainingDeposit,proto3" json:"remaining_deposit,omitempty"`
+	LiquidateThreshold string `protobuf:"bytes,8,opt,name=liquidate_threshold,json=liquidateThreshold,proto3" json:"liquidate_threshold,omitempty"`
+	// Liquidatable reports whether the remaining deposit falls below the
+	// liquidation threshold.
+	Liquidatable bool `protobuf:"varint,9,opt,name=liquidatable,proto3" json:"liquidatable,omitempty"`
+}
+
+func (x *Rental) Reset() {
+	*x = Rental{}
+	mi := &file_justlend_v1_justlend_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rental) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rental) ProtoMessage() {}
+
+func (x *Rental) ProtoReflect() protoreflect.Message {
+	mi := &file_justlend_v1_justlend_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rental.ProtoReflect.Descriptor instead.
+func (*Rental) Descriptor() ([]byte, []int) {
+	return file_justlend_v1_justlend_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Rental) GetRenter() string {
+	if x != nil {
+		return x.Renter
+	}
+	return ""
+}
+
+func (x *Rental) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *Rental) GetType() ResourceType {
+	if x != nil {
+		return x.Type
+	}
+	return ResourceType_BANDWIDTH
+}
+
+func (x *Rental) GetStakePerTrx() int64 {
+	if x != nil {
+		return x.StakePerTrx
+	}
+	return 0
+}
+
+func (x *Rental) GetSecurityDeposit() string {
+	if x != nil {
+		return x.SecurityDeposit
+	}
+	return ""
+}
+
+func (x *Rental) GetAccruedFee() string {
+	if x != nil {
+		return x.AccruedFee
+	}
+	return ""
+}
+
+func (x *Rental) GetRemainingDeposit() string {
+	if x != nil {
+		return x.RemainingDeposit
+	}
+	return ""
+}
+
+func (x *Rental) GetLiquidateThreshold() string {
+	if x != nil {
+		return x.LiquidateThreshold
+	}
+	return ""
+}
+
+func (x *Rental) GetLiquidatable() bool {
+	if x != nil {
+		return x.Liquidatable
+	}
+	return false
+}
+
+var File_justlend_v1_justlend_proto protoreflect.FileDescriptor
+
+var file_justlend_v1_justlend_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2f, 0x76, 0x31, 0x2f, 0x6a, 0x75,
+	0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6a, 0x75,
+	0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x22, 0x9d, 0x01, 0x0a, 0x0c, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x19, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x70, 0x61,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x72, 0x65, 0x70, 0x61, 0x79, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xc4, 0x03, 0x0a, 0x05, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x50, 0x65, 0x72,
+	0x54, 0x72, 0x78, 0x12, 0x2f, 0x0a, 0x13, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x12, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x65, 0x52, 0x61, 0x74,
+	0x69, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x63,
+	0x75, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x46, 0x65, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12,
+	0x19, 0x0a, 0x08, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x65, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x72,
+	0x65, 0x5f, 0x70, 0x61, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x65, 0x50, 0x61, 0x79, 0x46, 0x65, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x65, 0x70, 0x61, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x72, 0x65, 0x70,
+	0x61, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x5f, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x52,
+	0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x52, 0x65,
+	0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x22, 0xff, 0x02, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x13, 0x0a, 0x05,
+	0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x65, 0x72, 0x67,
+	0x79, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x6e,
+	0x65, 0x72, 0x67, 0x79, 0x55, 0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x65, 0x72,
+	0x67, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e,
+	0x65, 0x72, 0x67, 0x79, 0x46, 0x65, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x5f, 0x75,
+	0x73, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x65, 0x74, 0x46, 0x65, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0xf1, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x2d,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6a,
+	0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x65, 0x70, 0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x72, 0x65, 0x70,
+	0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x22, 0xac, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x78, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x65,
+	0x72, 0x5f, 0x74, 0x72, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61,
+	0x6b, 0x65, 0x50, 0x65, 0x72, 0x54, 0x72, 0x78, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x75, 0x73, 0x74,
+	0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52,
+	0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x41, 0x74, 0x22, 0xcb, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x74, 0x72, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x6b, 0x65,
+	0x50, 0x65, 0x72, 0x54, 0x72, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x77,
+	0x61, 0x69, 0x74, 0x22, 0x91, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x13,
+	0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x78, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x65, 0x72,
+	0x5f, 0x74, 0x72, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x6b,
+	0x65, 0x50, 0x65, 0x72, 0x54, 0x72, 0x78, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c,
+	0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x22, 0x75, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12,
+	0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
+	0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x93,
+	0x01, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65,
+	0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x22, 0xdd, 0x02, 0x0a, 0x06, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x74, 0x72, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x6b, 0x65,
+	0x50, 0x65, 0x72, 0x54, 0x72, 0x78, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x5f, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x72, 0x75, 0x65, 0x64, 0x5f, 0x66, 0x65, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x72, 0x75, 0x65, 0x64, 0x46,
+	0x65, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
+	0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72,
+	0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x12,
+	0x2f, 0x0a, 0x13, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x69,
+	0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x2a, 0x29, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x41, 0x4e, 0x44, 0x57, 0x49, 0x44, 0x54,
+	0x48, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x4e, 0x45, 0x52, 0x47, 0x59, 0x10, 0x01, 0x32,
+	0xcc, 0x02, 0x0a, 0x08, 0x4a, 0x75, 0x73, 0x74, 0x4c, 0x65, 0x6e, 0x64, 0x12, 0x38, 0x0a, 0x05,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x30, 0x00, 0x12, 0x3a, 0x0a, 0x04, 0x52, 0x65, 0x6e, 0x74, 0x12, 0x18,
+	0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c,
+	0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x30, 0x00, 0x12, 0x40, 0x0a, 0x06, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x1a, 0x2e, 0x6a,
+	0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c,
+	0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x30, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x12, 0x1d, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x30, 0x00, 0x12, 0x45, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1f, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65,
+	0x6e, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x30, 0x01, 0x42, 0x31,
+	0x5a, 0x2f, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x6a, 0x75, 0x73, 0x74, 0x6c,
+	0x65, 0x6e, 0x64, 0x2f, 0x76, 0x31, 0x3b, 0x6a, 0x75, 0x73, 0x74, 0x6c, 0x65, 0x6e, 0x64, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_justlend_v1_justlend_proto_rawDescOnce sync.Once
+	file_justlend_v1_justlend_proto_rawDescData = file_justlend_v1_justlend_proto_rawDesc
+)
+
+func file_justlend_v1_justlend_proto_rawDescGZIP() []byte {
+	file_justlend_v1_justlend_proto_rawDescOnce.Do(func() {
+		file_justlend_v1_justlend_proto_rawDescData = protoimpl.X.CompressGZIP(file_justlend_v1_justlend_proto_rawDescData)
+	})
+	return file_justlend_v1_justlend_proto_rawDescData
+}
+
+var file_justlend_v1_justlend_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_justlend_v1_justlend_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_justlend_v1_justlend_proto_goTypes = []any{
+	(ResourceType)(0),          // 0: justlend.v1.ResourceType
+	(*QuoteRequest)(nil),       // 1: justlend.v1.QuoteRequest
+	(*Quote)(nil),              // 2: justlend.v1.Quote
+	(*Receipt)(nil),            // 3: justlend.v1.Receipt
+	(*RentRequest)(nil),        // 4: justlend.v1.RentRequest
+	(*RentReply)(nil),          // 5: justlend.v1.RentReply
+	(*ReturnRequest)(nil),      // 6: justlend.v1.ReturnRequest
+	(*ReturnReply)(nil),        // 7: justlend.v1.ReturnReply
+	(*GetRentalRequest)(nil),   // 8: justlend.v1.GetRentalRequest
+	(*WatchRentalRequest)(nil), // 9: justlend.v1.WatchRentalRequest
+	(*Rental)(nil),             // 10: justlend.v1.Rental
+}
+var file_justlend_v1_justlend_proto_depIdxs = []int32{
+	0,  // 0: justlend.v1.QuoteRequest.type:type_name -> justlend.v1.ResourceType
+	0,  // 1: justlend.v1.RentRequest.type:type_name -> justlend.v1.ResourceType
+	3,  // 2: justlend.v1.RentReply.receipt:type_name -> justlend.v1.Receipt
+	0,  // 3: justlend.v1.ReturnRequest.type:type_name -> justlend.v1.ResourceType
+	3,  // 4: justlend.v1.ReturnReply.receipt:type_name -> justlend.v1.Receipt
+	0,  // 5: justlend.v1.GetRentalRequest.type:type_name -> justlend.v1.ResourceType
+	0,  // 6: justlend.v1.WatchRentalRequest.type:type_name -> justlend.v1.ResourceType
+	0,  // 7: justlend.v1.Rental.type:type_name -> justlend.v1.ResourceType
+	1,  // 8: justlend.v1.JustLend.Quote:input_type -> justlend.v1.QuoteRequest
+	4,  // 9: justlend.v1.JustLend.Rent:input_type -> justlend.v1.RentRequest
+	6,  // 10: justlend.v1.JustLend.Return:input_type -> justlend.v1.ReturnRequest
+	8,  // 11: justlend.v1.JustLend.GetRental:input_type -> justlend.v1.GetRentalRequest
+	9,  // 12: justlend.v1.JustLend.WatchRental:input_type -> justlend.v1.WatchRentalRequest
+	2,  // 13: justlend.v1.JustLend.Quote:output_type -> justlend.v1.Quote
+	5,  // 14: justlend.v1.JustLend.Rent:output_type -> justlend.v1.RentReply
+	7,  // 15: justlend.v1.JustLend.Return:output_type -> justlend.v1.ReturnReply
+	10, // 16: justlend.v1.JustLend.GetRental:output_type -> justlend.v1.Rental
+	10, // 17: justlend.v1.JustLend.WatchRental:output_type -> justlend.v1.Rental
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_justlend_v1_justlend_proto_init() }
+func file_justlend_v1_justlend_proto_init() {
+	if File_justlend_v1_justlend_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_justlend_v1_justlend_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_justlend_v1_justlend_proto_goTypes,
+		DependencyIndexes: file_justlend_v1_justlend_proto_depIdxs,
+		EnumInfos:         file_justlend_v1_justlend_proto_enumTypes,
+		MessageInfos:      file_justlend_v1_justlend_proto_msgTypes,
+	}.Build()
+	File_justlend_v1_justlend_proto = out.File
+	file_justlend_v1_justlend_proto_rawDesc = nil
+	file_justlend_v1_justlend_proto_goTypes = nil
+	file_justlend_v1_justlend_proto_depIdxs = nil
+}
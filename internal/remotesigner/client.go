@@ -0,0 +1,136 @@
+// Package remotesigner implements a tron.Signer which delegates the signing to
+// a separate signer process over HTTP or gRPC, so that the keys can live in a
+// hardened process rather than the daemon, together with the reference signer
+// server.
+//
+// The protocol is a JSON call:
+//
+//	POST /v1/sign
+//	Authorization: Bearer <token>
+//	{"address": "T...", "hash": "<hex raw data hash>"}
+//
+// which responds {"signature": "<hex 65 bytes signature>"} on success, along
+// with the listing of the addresses of the signer:
+//
+//	GET /v1/addresses
+//	Authorization: Bearer <token>
+//
+// which responds {"addresses": ["T..."]}. The gRPC API is the signer.v1.Signer
+// service, which carries the bearer token in the authorization metadata.
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"justlend/internal/derrors"
+	"justlend/internal/tron"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// signPath & addressesPath are the paths of the calls.
+const (
+	signPath      = "/v1/sign"
+	addressesPath = "/v1/addresses"
+)
+
+type signRequest struct {
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type addressesResponse struct {
+	Addresses []string `json:"addresses"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// Client is a tron.Signer calling a remote signer server.
+type Client struct {
+	url   string
+	token string
+	http  *http.Client
+}
+
+// NewClient creates a new client to the signer server of the given base URL,
+// the token is sent as a bearer token if not empty.
+func NewClient(url, token string) *Client {
+	return &Client{
+		url:   strings.TrimSuffix(url, "/"),
+		token: token,
+		http:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Sign implements tron.Signer.
+func (c *Client) Sign(ctx context.Context, address string, hash []byte) (_ []byte, err error) {
+	defer derrors.Wrap(&err, "remotesigner.Sign(%q)", address)
+
+	body, err := json.Marshal(signRequest{Address: address, Hash: hex.EncodeToString(hash)})
+	if err != nil {
+		return nil, err
+	}
+	var r signResponse
+	if err = c.do(ctx, http.MethodPost, signPath, body, &r); err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(r.Signature)
+	if err != nil || len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature %q", r.Signature)
+	}
+	return sig, nil
+}
+
+// Addresses returns the addresses of the signer server.
+func (c *Client) Addresses(ctx context.Context) (_ []string, err error) {
+	defer derrors.Wrap(&err, "remotesigner.Addresses()")
+
+	var r addressesResponse
+	if err = c.do(ctx, http.MethodGet, addressesPath, nil, &r); err != nil {
+		return nil, err
+	}
+	return r.Addresses, nil
+}
+
+// do sends the call to the server & decodes the response into v, the error
+// statuses are mapped to the derrors codes.
+func (c *Client) do(ctx context.Context, method, path string, body []byte, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", derrors.Unavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return derrors.NotFound
+	case http.StatusUnauthorized:
+		return derrors.Unauthenticated
+	case http.StatusForbidden:
+		return derrors.Forbidden
+	default:
+		return fmt.Errorf("%w: status %d", derrors.Unavailable, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+var (
+	_ tron.Signer = (*Client)(nil)
+)
@@ -0,0 +1,166 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"justlend/internal/derrors"
+	"justlend/internal/log"
+	signerv1 "justlend/internal/protos/signer/v1"
+	"justlend/internal/tron"
+	"strings"
+)
+
+// authMetadata is the metadata key of the bearer token of the gRPC calls, the
+// counterpart of the Authorization header of the HTTP calls.
+const authMetadata = "authorization"
+
+// GRPCClient is a tron.Signer calling a remote signer server over gRPC.
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client signerv1.SignerClient
+	token  string
+}
+
+// NewGRPCClient creates a new client to the gRPC signer server of the given
+// address, with the transport security if useTLS is set. The token is sent as
+// a bearer token if not empty.
+func NewGRPCClient(addr, token string, useTLS bool) (*GRPCClient, error) {
+	creds := insecure.NewCredentials()
+	if useTLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("dial signer %s: %w", addr, err)
+	}
+	return &GRPCClient{conn: conn, client: signerv1.NewSignerClient(conn), token: token}, nil
+}
+
+// Sign implements tron.Signer.
+func (c *GRPCClient) Sign(ctx context.Context, address string, hash []byte) (_ []byte, err error) {
+	defer derrors.Wrap(&err, "remotesigner.Sign(%q)", address)
+
+	r, err := c.client.Sign(c.outgoing(ctx), &signerv1.SignRequest{Address: address, Hash: hash})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	if len(r.Signature) != 65 {
+		return nil, fmt.Errorf("invalid signature %x", r.Signature)
+	}
+	return r.Signature, nil
+}
+
+// Addresses returns the addresses of the signer server.
+func (c *GRPCClient) Addresses(ctx context.Context) (_ []string, err error) {
+	defer derrors.Wrap(&err, "remotesigner.Addresses()")
+
+	r, err := c.client.ListAddresses(c.outgoing(ctx), &signerv1.ListAddressesRequest{})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return r.Addresses, nil
+}
+
+// Close closes the connection to the server.
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// outgoing returns the context of a call carrying the bearer token.
+func (c *GRPCClient) outgoing(ctx context.Context) context.Context {
+	if c.token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, authMetadata, "Bearer "+c.token)
+}
+
+// fromStatus maps the status codes of the server to the derrors codes.
+func fromStatus(err error) error {
+	s, _ := status.FromError(err)
+	switch s.Code() {
+	case codes.NotFound:
+		return derrors.NotFound
+	case codes.Unauthenticated:
+		return derrors.Unauthenticated
+	case codes.PermissionDenied:
+		return derrors.Forbidden
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", derrors.InvalidParam, s.Message())
+	default:
+		return fmt.Errorf("%w: %v", derrors.Unavailable, err)
+	}
+}
+
+// grpcServer serves the signer.v1 API with a signer.
+type grpcServer struct {
+	signerv1.UnimplementedSignerServer
+
+	signer tron.Signer
+	token  string
+}
+
+// NewGRPCServer returns the gRPC server of the reference signer server, which
+// signs the hashes with the given signer. Calls without the bearer token are
+// rejected if the token is not empty.
+func NewGRPCServer(signer tron.Signer, token string) *grpc.Server {
+	s := &grpcServer{signer: signer, token: token}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(s.authorize))
+	signerv1.RegisterSignerServer(srv, s)
+	return srv
+}
+
+// Sign implements signerv1.SignerServer.
+func (s *grpcServer) Sign(ctx context.Context, req *signerv1.SignRequest) (*signerv1.SignReply, error) {
+	// Only the 32 bytes sha256 hashes of raw data are signed.
+	if len(req.Hash) != 32 {
+		return nil, status.Error(codes.InvalidArgument, "invalid hash")
+	}
+	sig, err := s.signer.Sign(ctx, req.Address, req.Hash)
+	switch {
+	case errors.Is(err, derrors.NotFound):
+		return nil, status.Error(codes.NotFound, "unknown address")
+	case err != nil:
+		log.ErrorW("fails to sign", "address", req.Address, "error", err)
+		return nil, status.Error(codes.Internal, "internal")
+	}
+	log.InfoW("signed", "address", req.Address, "hash", hex.EncodeToString(req.Hash))
+	return &signerv1.SignReply{Signature: sig}, nil
+}
+
+// ListAddresses implements signerv1.SignerServer.
+func (s *grpcServer) ListAddresses(context.Context, *signerv1.ListAddressesRequest) (*signerv1.ListAddressesReply, error) {
+	l, ok := s.signer.(tron.Lister)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "not listable")
+	}
+	return &signerv1.ListAddressesReply{Addresses: l.Addresses()}, nil
+}
+
+// authorize rejects the calls without the bearer token if it's not empty.
+func (s *grpcServer) authorize(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.token != "" {
+		var got string
+		if vs := metadata.ValueFromIncomingContext(ctx, authMetadata); len(vs) > 0 {
+			got = strings.TrimPrefix(vs[0], "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+		}
+	}
+	return handler(ctx, req)
+}
+
+var (
+	_ tron.Signer = (*GRPCClient)(nil)
+)
@@ -0,0 +1,170 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"justlend/internal/derrors"
+	"justlend/internal/tron"
+	"net"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const (
+	key   = "8e812436a0e3323166e1f0e8ba79e19e217b2c4a53c970d4cca0cfb1078979df"
+	token = "secret"
+)
+
+// newServer runs the signer server of a local signer of the key.
+func newServer(t *testing.T) (*httptest.Server, *tron.LocalSigner) {
+	t.Helper()
+	signer, err := tron.NewLocalSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(NewHandler(signer, token))
+	t.Cleanup(srv.Close)
+	return srv, signer
+}
+
+func TestSign(t *testing.T) {
+	srv, signer := newServer(t)
+	ctx := context.Background()
+	address := signer.Addresses()[0]
+	hash := sha256.Sum256([]byte("raw data"))
+
+	got, err := NewClient(srv.URL+"/", token).Sign(ctx, address, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The signatures are deterministic, so the remote one is the local one.
+	want, err := signer.Sign(ctx, address, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Sign() = %x, want %x", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		address string
+		hash    []byte
+		wantErr error
+	}{
+		{name: "wrong token", token: "wrong", address: address, hash: hash[:], wantErr: derrors.Unauthenticated},
+		{name: "no token", address: address, hash: hash[:], wantErr: derrors.Unauthenticated},
+		{name: "unknown address", token: token, address: tron.ZeroAddress, hash: hash[:], wantErr: derrors.NotFound},
+		{name: "short hash", token: token, address: address, hash: hash[:16], wantErr: derrors.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(srv.URL, tt.token).Sign(ctx, tt.address, tt.hash)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Sign() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	srv, signer := newServer(t)
+	ctx := context.Background()
+
+	got, err := NewClient(srv.URL, token).Addresses(ctx)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, signer.Addresses()) {
+		t.Errorf("Addresses() = %v, want %v", got, signer.Addresses())
+	}
+	if _, err = NewClient(srv.URL, "wrong").Addresses(ctx); !errors.Is(err, derrors.Unauthenticated) {
+		t.Errorf("Addresses() of a wrong token: error = %v, want %v", err, derrors.Unauthenticated)
+	}
+}
+
+// newGRPCServer runs the gRPC signer server of a local signer of the key.
+func newGRPCServer(t *testing.T) (string, *tron.LocalSigner) {
+	t.Helper()
+	signer, err := tron.NewLocalSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewGRPCServer(signer, token)
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+	return ln.Addr().String(), signer
+}
+
+// newGRPCClient returns a client of the token to the gRPC server.
+func newGRPCClient(t *testing.T, addr, token string) *GRPCClient {
+	t.Helper()
+	c, err := NewGRPCClient(addr, token, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestGRPCSign(t *testing.T) {
+	addr, signer := newGRPCServer(t)
+	ctx := context.Background()
+	address := signer.Addresses()[0]
+	hash := sha256.Sum256([]byte("raw data"))
+
+	got, err := newGRPCClient(t, addr, token).Sign(ctx, address, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := signer.Sign(ctx, address, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Sign() = %x, want %x", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		address string
+		hash    []byte
+		wantErr error
+	}{
+		{name: "wrong token", token: "wrong", address: address, hash: hash[:], wantErr: derrors.Unauthenticated},
+		{name: "no token", address: address, hash: hash[:], wantErr: derrors.Unauthenticated},
+		{name: "unknown address", token: token, address: tron.ZeroAddress, hash: hash[:], wantErr: derrors.NotFound},
+		{name: "short hash", token: token, address: address, hash: hash[:16], wantErr: derrors.InvalidParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newGRPCClient(t, addr, tt.token).Sign(ctx, tt.address, tt.hash)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Sign() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGRPCAddresses(t *testing.T) {
+	addr, signer := newGRPCServer(t)
+	ctx := context.Background()
+
+	got, err := newGRPCClient(t, addr, token).Addresses(ctx)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, signer.Addresses()) {
+		t.Errorf("Addresses() = %v, want %v", got, signer.Addresses())
+	}
+	if _, err = newGRPCClient(t, addr, "wrong").Addresses(ctx); !errors.Is(err, derrors.Unauthenticated) {
+		t.Errorf("Addresses() of a wrong token: error = %v, want %v", err, derrors.Unauthenticated)
+	}
+}
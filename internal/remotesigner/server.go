@@ -0,0 +1,81 @@
+package remotesigner
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"justlend/internal/derrors"
+	"justlend/internal/log"
+	"justlend/internal/tron"
+	"net/http"
+	"strings"
+)
+
+// NewHandler returns the HTTP handler of the reference signer server, which
+// signs the hashes with the given signer. Requests without the bearer token
+// are rejected if the token is not empty.
+func NewHandler(signer tron.Signer, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(signPath, authorize(token, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, signResponse{Error: "method not allowed"})
+			return
+		}
+		var req signRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, signResponse{Error: "invalid request"})
+			return
+		}
+		// Only the 32 bytes sha256 hashes of raw data are signed.
+		hash, err := hex.DecodeString(req.Hash)
+		if err != nil || len(hash) != 32 {
+			writeJSON(w, http.StatusBadRequest, signResponse{Error: "invalid hash"})
+			return
+		}
+		sig, err := signer.Sign(r.Context(), req.Address, hash)
+		switch {
+		case errors.Is(err, derrors.NotFound):
+			writeJSON(w, http.StatusNotFound, signResponse{Error: "unknown address"})
+		case err != nil:
+			log.ErrorW("fails to sign", "address", req.Address, "error", err)
+			writeJSON(w, http.StatusInternalServerError, signResponse{Error: "internal"})
+		default:
+			log.InfoW("signed", "address", req.Address, "hash", req.Hash)
+			writeJSON(w, http.StatusOK, signResponse{Signature: hex.EncodeToString(sig)})
+		}
+	}))
+	mux.Handle(addressesPath, authorize(token, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeJSON(w, http.StatusMethodNotAllowed, addressesResponse{Error: "method not allowed"})
+			return
+		}
+		l, ok := signer.(tron.Lister)
+		if !ok {
+			writeJSON(w, http.StatusNotImplemented, addressesResponse{Error: "not listable"})
+			return
+		}
+		writeJSON(w, http.StatusOK, addressesResponse{Addresses: l.Addresses()})
+	}))
+	return mux
+}
+
+// authorize rejects the requests without the bearer token if it's not empty.
+func authorize(token string, h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				writeJSON(w, http.StatusUnauthorized, signResponse{Error: "unauthenticated"})
+				return
+			}
+		}
+		h(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
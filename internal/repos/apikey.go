@@ -0,0 +1,199 @@
+package repos
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"strings"
+)
+
+// apiKeyPrefix tells the API keys apart from the other secrets.
+const apiKeyPrefix = "jlk_"
+
+func (ls *Service) IssueAPIKey(ctx context.Context, req *justlend.APIKeyMeta) (_ *justlend.APIKeyRL, err error) {
+	defer derrors.WrapStack(&err, "ls.IssueAPIKey()")
+
+	blob := make([]byte, 32)
+	if _, err = rand.Read(blob); err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(blob)
+	scopes := make([]string, len(req.Scopes))
+	for i, s := range req.Scopes {
+		scopes[i] = string(s)
+	}
+	id, at := uuid.NewString(), now()
+	if _, err = ls.db.Exec(ctx, `
+		INSERT INTO api_keys (id, name, key_hash, scopes, wallets, receivers, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		id, req.Name, hashAPIKey(key), strings.Join(scopes, ","), strings.Join(req.Wallets, ","),
+		strings.Join(req.Receivers, ","), at); err != nil {
+		return nil, err
+	}
+	k, err := ls.getAPIKey(ctx, ` WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	k.Key = key
+	return k, nil
+}
+
+func (ls *Service) APIKeys(ctx context.Context,
+	req *justlend.APIKeysMeta) (_ []*justlend.APIKeyRL, _ int, err error) {
+	defer derrors.WrapStack(&err, "ls.APIKeys()")
+
+	var total int
+	if err = ls.db.QueryRow(ctx, `SELECT COUNT(*) FROM api_keys`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	ks, err := ls.findAPIKeys(ctx, ` ORDER BY created_at, id`+limitOffset(req.Limit, req.Offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	return ks, total, nil
+}
+
+func (ls *Service) RevokeAPIKey(ctx context.Context, req *justlend.APIKeyIdMeta) (err error) {
+	defer derrors.WrapStack(&err, "ls.RevokeAPIKey()")
+
+	n, err := ls.db.Exec(ctx, `UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`,
+		now(), req.Id)
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return derrors.NotFound
+	}
+	return nil
+}
+
+func (ls *Service) Authenticate(ctx context.Context, key string) (_ *justlend.APIKeyRL, err error) {
+	defer derrors.WrapStack(&err, "ls.Authenticate()")
+
+	k, err := ls.getAPIKey(ctx, ` WHERE key_hash = $1 AND revoked_at IS NULL`, hashAPIKey(key))
+	if errors.Is(err, derrors.NotFound) {
+		return nil, derrors.Unauthenticated
+	}
+	return k, err
+}
+
+// hashAPIKey returns the stored hash of the key. The keys are random enough
+// that a plain SHA-256 can't be brute forced, & it keeps them searchable.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+const apiKeyColumns = `id, name, scopes, wallets, receivers, created_at, revoked_at`
+
+func (ls *Service) getAPIKey(ctx context.Context, clauses string, args ...any) (*justlend.APIKeyRL, error) {
+	ks, err := ls.findAPIKeys(ctx, clauses, args...)
+	if err != nil {
+		return nil, err
+	} else if len(ks) == 0 {
+		return nil, derrors.NotFound
+	}
+	return ks[0], nil
+}
+
+func (ls *Service) findAPIKeys(ctx context.Context, clauses string, args ...any) ([]*justlend.APIKeyRL, error) {
+	var ks []*justlend.APIKeyRL
+	err := ls.db.RunQuery(ctx, `SELECT `+apiKeyColumns+` FROM api_keys`+clauses, func(rows *sql.Rows) error {
+		var (
+			k                          justlend.APIKeyRL
+			scopes, wallets, receivers string
+			revokedAt                  sql.NullTime
+		)
+		if err := rows.Scan(&k.Id, &k.Name, &scopes, &wallets, &receivers, &k.CreatedAt, &revokedAt); err != nil {
+			return err
+		}
+		for _, s := range splitList(scopes) {
+			k.Scopes = append(k.Scopes, justlend.APIKeyScope(s))
+		}
+		k.Wallets, k.Receivers = splitList(wallets), splitList(receivers)
+		if revokedAt.Valid {
+			k.RevokedAt = &revokedAt.Time
+		}
+		ks = append(ks, &k)
+		return nil
+	}, args...)
+	return ks, err
+}
+
+// splitList splits a comma separated column, it's empty rather than nil if
+// the column is.
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
+
+// access restricts the orders of the requests of an API key to the owners &
+// the receivers allowed to the key, either is unrestricted if it's nil.
+type access struct {
+	owners, receivers []string
+}
+
+// accessOf returns the restriction of the API key of the request, the wallets
+// of the key are resolved into the addresses that own their orders.
+func (ls *Service) accessOf(ctx context.Context) access {
+	var a access
+	k, ok := justlend.APIKeyFrom(ctx)
+	if !ok {
+		return a
+	}
+	if len(k.Wallets) > 0 {
+		a.owners = []string{}
+		for _, w := range k.Wallets {
+			// A wallet that's no longer resolved owns nothing visible.
+			if _, owner, err := ls.payer(w, ""); err == nil {
+				a.owners = append(a.owners, owner)
+			}
+		}
+	}
+	if len(k.Receivers) > 0 {
+		a.receivers = k.Receivers
+	}
+	return a
+}
+
+// allows reports whether the order is visible to the key, the orders without
+// a receiver are only restricted by their owner.
+func (a access) allows(o *justlend.OrderRL) bool {
+	if a.owners != nil && !internal.Contains(o.Owner, a.owners...) {
+		return false
+	}
+	return a.receivers == nil || o.Receiver == "" || internal.Contains(o.Receiver, a.receivers...)
+}
+
+// where adds the conditions of the restriction on the owner & the receiver
+// columns of the orders to the query arguments.
+func (a access) where(owner, receiver string, conds []string, args []any) ([]string, []any) {
+	in := func(column string, addresses []string) string {
+		if len(addresses) == 0 {
+			return "1 = 0"
+		}
+		marks := make([]string, len(addresses))
+		for i, addr := range addresses {
+			args = append(args, internal.Address(internal.DecodeCheck(addr)))
+			marks[i] = fmt.Sprintf("$%d", len(args))
+		}
+		return column + " IN (" + strings.Join(marks, ", ") + ")"
+	}
+	if a.owners != nil {
+		conds = append(conds, in(owner, a.owners))
+	}
+	if a.receivers != nil {
+		conds = append(conds, "("+receiver+" IS NULL OR "+in(receiver, a.receivers)+")")
+	}
+	return conds, args
+}
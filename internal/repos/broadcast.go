@@ -0,0 +1,59 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/tron"
+	"time"
+)
+
+func (ls *Service) Broadcast(ctx context.Context,
+	req *justlend.BroadcastMeta) (_ *justlend.BroadcastRL, err error) {
+	defer derrors.WrapStack(&err, "ls.Broadcast()")
+
+	tx, err := tron.DecodeTransaction(req.Transaction)
+	if err != nil {
+		return nil, err
+	} else if len(tx.GetSignature()) == 0 {
+		return nil, fmt.Errorf("%w: transaction not signed", derrors.InvalidParam)
+	}
+	txId, err := tron.TransactionId(tx)
+	if err != nil {
+		return nil, err
+	}
+	// The txID identifies the raw data signed, so the order of the unsigned
+	// transaction is found by it. The transactions built elsewhere are
+	// broadcast without an order.
+	orders, _, err := ls.findOrders(ctx, &justlend.OrdersMeta{TxId: txId, Status: justlend.OrderCreated}, access{}, false)
+	if err != nil {
+		return nil, err
+	}
+	// A failed broadcast leaves the order created, since the transaction
+	// may be signed again until it expires.
+	if err = ls.tron.Broadcast(ctx, tx); err != nil {
+		return nil, err
+	}
+	rl := &justlend.BroadcastRL{TxId: txId}
+	if len(orders) == 0 {
+		if req.Wait {
+			rl.Receipt, err = ls.confirm(ctx, txId, tx)
+		}
+		return rl, err
+	}
+	o := orders[0]
+	rl.OrderId = o.Id
+	ls.logTransition(ctx, o.Id, justlend.OrderBroadcast, orderChange{
+		TxId:      txId,
+		ExpiresAt: time.UnixMilli(tx.GetRawData().GetExpiration()),
+	})
+	if !req.Wait {
+		return rl, nil
+	}
+	if rl.Receipt, err = ls.confirm(ctx, txId, tx); err != nil {
+		return nil, err
+	}
+	ls.settle(ctx, o, rl.Receipt)
+	return rl, nil
+}
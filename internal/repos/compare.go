@@ -0,0 +1,73 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/protos/core"
+	"math/big"
+)
+
+const secondsPerDay = 24 * 3600
+
+func (ls *Service) Compare(ctx context.Context, req *justlend.CompareMeta) (_ *justlend.CompareRL, err error) {
+	defer derrors.WrapStack(&err, "ls.Compare()")
+
+	p, err := ls.rentParamsOf(ctx, req.Owner, req.Energy, core.ResourceCode_ENERGY, false)
+	if err != nil {
+		return nil, err
+	}
+	price, err := ls.tron.EnergyPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	delay, err := ls.tron.UnfreezeDelayDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The rental is quoted for the duration if it may be prepaid.
+	prepay := min(max(req.Duration, justlend.MinPrepay), justlend.MaxPrepay)
+	rental := quoteOf(p, req.Energy, prepay)
+	days := (req.Duration + secondsPerDay - 1) / secondsPerDay
+
+	rl := &justlend.CompareRL{
+		Energy:            req.Energy,
+		Duration:          req.Duration,
+		Days:              days,
+		Rental:            rental,
+		RentCost:          toTRX(new(big.Int).Add(rentOf(p, req.Duration), rentFees(p, prepay).fee)),
+		BurnCost:          toTRX(new(big.Int).Mul(big.NewInt(req.Energy*price), big.NewInt(days))),
+		EnergyPrice:       price,
+		FreezeTrx:         p.stakePerTrx,
+		UnfreezeDelayDays: delay,
+	}
+	if !internal.IsEmpty(req.Owner) {
+		balance, err := ls.tron.Balance(ctx, req.Owner)
+		if err != nil {
+			return nil, err
+		}
+		b := decimal.New(balance, -6)
+		rl.Balance = &b
+	}
+	rl.Recommendation, rl.Reason = recommend(rl)
+	return rl, nil
+}
+
+// recommend recommends the option of getting the energy. Staking burns
+// nothing, but it's only worth locking the TRX for at least the unstake delay
+// and only if the balance covers it. Otherwise the cheaper of renting &
+// burning is recommended.
+func recommend(rl *justlend.CompareRL) (justlend.CostOption, string) {
+	if rl.Balance != nil && rl.Days >= rl.UnfreezeDelayDays &&
+		rl.Balance.GreaterThanOrEqual(decimal.NewFromInt(rl.FreezeTrx)) {
+		return justlend.OptionFreeze, fmt.Sprintf("the balance covers the stake of %d TRX, "+
+			"which is needed for at least the unstake delay of %d days", rl.FreezeTrx, rl.UnfreezeDelayDays)
+	}
+	if rl.RentCost.LessThan(rl.BurnCost) {
+		return justlend.OptionRent, fmt.Sprintf("renting saves %s TRX", rl.BurnCost.Sub(rl.RentCost))
+	}
+	return justlend.OptionBurn, fmt.Sprintf("burning saves %s TRX", rl.RentCost.Sub(rl.BurnCost))
+}
@@ -2,128 +2,258 @@ package repos
 
 import (
 	"context"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"justlend/internal"
 	"justlend/internal/derrors"
 	"justlend/internal/justlend"
+	"justlend/internal/log"
 	"justlend/internal/protos/core"
+	"justlend/internal/tron"
 	"math/big"
+	"sync"
 )
 
 func (ls *Service) FeeRatio(ctx context.Context, req *justlend.FeeRatioMeta) (_ *justlend.FeeRatioRL, err error) {
 	defer derrors.WrapStack(&err, "ls.FeeRatio()")
 
-	pub := internal.PrivateKeyToPublicKey(req.PrivateKey)
-	address := internal.EncodeCheck(internal.PublicKeyToTronAddress(pub))
-
-	_liquidateThreshold, _ := ls.liquidateThreshold(ctx, address, req.PrivateKey)
-
-	stakePerTrx, err := ls.tron.CalStackEnergy(ctx, address, req.Energy, false)
+	rl, err := ls.quote(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	// The quote is served even if it can't be recorded.
+	if rl.OrderId, err = ls.recordQuote(ctx, req, rl); err != nil {
+		log.ErrorW("fails to record quote", "error", err)
+	}
+	return rl, nil
+}
 
-	rentalRate, _ := ls.getRentalRate(ctx, stakePerTrx, req.PrivateKey, req.Type)
-	_feeRatio, _ := ls.feeRatio(ctx, address, req.PrivateKey)
-	_minFee, _ := ls.minFee(ctx, address, req.PrivateKey)
+// recordQuote records the quote in the ledger & returns its ID, the quotes
+// are kept for the retention window only.
+func (ls *Service) recordQuote(ctx context.Context, req *justlend.FeeRatioMeta, rl *justlend.FeeRatioRL) (string, error) {
+	owner := req.Owner
+	if internal.IsEmpty(owner) {
+		owner = tron.ZeroAddress
+	}
+	id := uuid.NewString()
+	if _, err := ls.db.Exec(ctx, `
+		INSERT INTO quotes (id, owner, resource_type, amount, stake_per_trx, fee, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		id, internal.Address(internal.DecodeCheck(owner)), int32(req.Type), req.Energy,
+		rl.StakePerTrx*tron.SUNPerTRX, tron.ToSUN(rl.PrePayFee.Decimal), now()); err != nil {
+		return "", err
+	}
+	return id, nil
+}
 
-	curFeeRatio := _feeRatio.Mul(decimal.NewFromInt(stakePerTrx))
-	feeRatio := decimal.Zero
-	if _minFee.Compare(curFeeRatio) > 0 {
-		feeRatio = _minFee
-	} else {
-		feeRatio = curFeeRatio
+// quote calculates the fee of renting the resource.
+func (ls *Service) quote(ctx context.Context, req *justlend.FeeRatioMeta) (*justlend.FeeRatioRL, error) {
+	p, err := ls.rentParamsOf(ctx, req.Owner, req.Energy, req.Type, req.Partial)
+	if err != nil {
+		return nil, err
 	}
-	countFeeDuration := decimal.NewFromInt(172800)
-	rentFee := decimal.NewFromInt(stakePerTrx).
-		Mul(rentalRate).
-		Mul(countFeeDuration).
-		Add(_liquidateThreshold)
-	prePayFee, _ := rentFee.Add(feeRatio).Float64()
+	prepay := req.Prepay
+	if prepay == 0 {
+		prepay = justlend.DefaultPrepay
+	}
+	return quoteOf(p, req.Energy, prepay), nil
+}
+
+// quoteOf returns the quote of renting the energy with the parameters.
+func quoteOf(p *rentParams, energy, prepay int64) *justlend.FeeRatioRL {
+	f := rentFees(p, prepay)
 	return &justlend.FeeRatioRL{
-		RentAmount:         req.Energy,
-		StakePerTrx:        stakePerTrx,
-		LiquidateThreshold: _liquidateThreshold,
-		RentalRate:         rentalRate,
-		FeeRatio:           feeRatio,
-		MinFee:             _minFee,
-		CurFeeRatio:        curFeeRatio,
-		RentFee:            rentFee,
-		PrePayFee:          prePayFee,
-	}, nil
+		RentAmount:         energy,
+		StakePerTrx:        p.stakePerTrx,
+		LiquidateThreshold: toTRX(p.threshold),
+		RentalRate:         decimal.NewFromBigInt(p.rate, -18),
+		FeeRatio:           toTRX(f.fee),
+		MinFee:             toTRX(p.minFee),
+		CurFeeRatio:        toTRX(f.curFee),
+		RentFee:            toTRX(f.rent),
+		PrePayFee:          justlend.DecimalNumber{Decimal: toTRX(f.prePay)},
+		Prepay:             prepay,
+		HourlyRent:         toTRX(rentOf(p, 3600)),
+		DailyRent:          toTRX(rentOf(p, 24*3600)),
+		Estimated:          p.estimated,
+	}
 }
 
-func (ls *Service) getRentalRate(ctx context.Context,
-	value int64,
-	privateKey string,
-	rt core.ResourceCode) (decimal.Decimal, error) {
-	data := []byte{}
-	methodId, _ := hexutil.Decode(justlend.GetRentalRateABI)
-	resourceType := common.LeftPadBytes(new(big.Int).SetInt64(int64(rt)).Bytes(), 32)
-	amount := common.LeftPadBytes(new(big.Int).SetInt64(value).Bytes(), 32)
-	data = append(data, methodId...)
-	data = append(data, amount...)
-	data = append(data, resourceType...)
-
-	result, _, err := ls.tron.TriggerConstantContract(
-		ctx,
-		justlend.JustLendContract,
-		data,
-		privateKey,
-		0,
-	)
+func (ls *Service) FeeSchedule(ctx context.Context,
+	req *justlend.FeeScheduleMeta) (_ *justlend.FeeScheduleRL, err error) {
+	defer derrors.WrapStack(&err, "ls.FeeSchedule()")
+
+	p, err := ls.rentParamsOf(ctx, req.Owner, req.Energy, req.Type, false)
 	if err != nil {
-		return decimal.Zero, nil
+		return nil, err
 	}
-	bigInt := new(big.Int).SetBytes(result.GetConstantResult()[0]).Int64()
-	d := decimal.NewFromInt(bigInt)
-	rentalRate := d.Div(decimal.NewFromInt(justlend.TokenDefaultPrecision))
-	return rentalRate, nil
+	// The fee doesn't depend on the duration.
+	fee := rentFees(p, 0).fee
+	rl := &justlend.FeeScheduleRL{
+		RentAmount:         req.Energy,
+		StakePerTrx:        p.stakePerTrx,
+		RentalRate:         decimal.NewFromBigInt(p.rate, -18),
+		FeeRatio:           toTRX(fee),
+		LiquidateThreshold: toTRX(p.threshold),
+		Items:              make([]*justlend.FeeScheduleItem, 0, len(req.Durations)),
+	}
+	for _, d := range req.Durations {
+		rent := rentOf(p, d)
+		rl.Items = append(rl.Items, &justlend.FeeScheduleItem{
+			Duration: d,
+			Rent:     toTRX(rent),
+			Total:    toTRX(new(big.Int).Add(rent, fee)),
+		})
+	}
+	return rl, nil
 }
 
-func (ls *Service) liquidateThreshold(ctx context.Context, address, privateKey string) (decimal.Decimal, error) {
+// rentParams are the parameters of the rental contract the fees of renting
+// the stake are calculated with. The rate & the ratio are scaled by 1e18, the
+// others are in SUN.
+type rentParams struct {
+	stakePerTrx int64
+	stake       *big.Int
+	rate, ratio *big.Int
+	minFee      *big.Int
+	threshold   *big.Int
+	// estimated lists the parameters of a partial read which are the last
+	// known values rather than read from the contract.
+	estimated []string
+}
 
-	data := []byte{}
+// paramCache holds the last known parameters of the rental contract, which
+// stand in for the parameters failed to be read by the partial quotes.
+type paramCache struct {
+	mu     sync.Mutex
+	values map[string]*big.Int
+}
 
-	methodId, _ := hexutil.Decode(justlend.LiquidateThresholdABI)
+func (c *paramCache) load(key string) *big.Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[key]
+}
 
-	data = append(data, methodId...)
-	data = append(data, common.LeftPadBytes(internal.DecodeCheck(address)[1:], 32)...)
-	data = append(data, common.LeftPadBytes(internal.DecodeCheck(justlend.JustLendContract)[1:], 32)...)
-	result, _, err := ls.tron.TriggerConstantContract(ctx, justlend.JustLendContract, data, privateKey, 0)
+func (c *paramCache) store(key string, v *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = v
+}
+
+// rentParamsOf reads the parameters of renting the resource, it fails with
+// derrors.Unavailable if any of them can't be read. If partial, the last
+// known values of the contract parameters are used instead, the rental rate
+// of the last amount rented of the resource type is used for the rate.
+func (ls *Service) rentParamsOf(ctx context.Context,
+	owner string,
+	amount int64,
+	rt core.ResourceCode,
+	partial bool) (*rentParams, error) {
+
+	// None of the calls below change the chain state, the owner is only used
+	// as the caller of the calls and the neutral caller is used if not given.
+	if internal.IsEmpty(owner) {
+		owner = tron.ZeroAddress
+	}
+
+	// The stake can't be estimated, since everything else depends on it.
+	stakePerTrx, err := ls.tron.CalStackEnergy(ctx, owner, amount, rt, false)
 	if err != nil {
-		return decimal.Zero, err
+		return nil, err
+	}
+	p := &rentParams{
+		stakePerTrx: stakePerTrx,
+		stake:       big.NewInt(stakePerTrx * tron.SUNPerTRX),
+	}
+	reads := []struct {
+		name, key string
+		dst       **big.Int
+		read      func() (*big.Int, error)
+	}{
+		{"liquidateThreshold", "liquidateThreshold", &p.threshold, func() (*big.Int, error) {
+			return ls.rental.LiquidateThreshold(ctx, owner)
+		}},
+		{"rentalRate", "rentalRate/" + rt.String(), &p.rate, func() (*big.Int, error) {
+			return ls.rental.RentalRate(ctx, owner, p.stake, rt)
+		}},
+		{"feeRatio", "feeRatio", &p.ratio, func() (*big.Int, error) {
+			return ls.rental.FeeRatio(ctx, owner)
+		}},
+		{"minFee", "minFee", &p.minFee, func() (*big.Int, error) {
+			return ls.rental.MinFee(ctx, owner)
+		}},
 	}
-	newLiquidateThreshold := new(big.Int).SetBytes(result.GetConstantResult()[0]).Int64()
-	return decimal.NewFromInt(newLiquidateThreshold).Div(decimal.NewFromInt(1000000)), nil
+	for _, r := range reads {
+		v, err := r.read()
+		if err == nil {
+			ls.params.store(r.key, v)
+			*r.dst = v
+			continue
+		}
+		err = fmt.Errorf("%w: %v", derrors.Unavailable, err)
+		last := ls.params.load(r.key)
+		if !partial || last == nil {
+			return nil, err
+		}
+		log.WarnW("estimates rental parameter", "param", r.name, "error", err)
+		*r.dst = last
+		p.estimated = append(p.estimated, r.name)
+	}
+	return p, nil
 }
 
-func (ls *Service) minFee(ctx context.Context, address, privateKey string) (decimal.Decimal, error) {
-	data := []byte{}
-	methodId, _ := hexutil.Decode(justlend.MinFeeABI)
-	data = append(data, methodId...)
-	data = append(data, common.LeftPadBytes(internal.DecodeCheck(address)[1:], 32)...)
-	data = append(data, common.LeftPadBytes(internal.DecodeCheck(justlend.JustLendContract)[1:], 32)...)
-	result, _, err := ls.tron.TriggerConstantContract(ctx, justlend.JustLendContract, data, privateKey, 0)
-	if err != nil {
-		return decimal.Zero, err
+// fees is the breakdown of the prepaid fee of a rental in SUN.
+type fees struct {
+	// rent is the rent of the prepay period plus the liquidation threshold.
+	rent *big.Int
+	// curFee is the fee of the fee ratio, fee is the larger of it & the
+	// minimum fee.
+	curFee, fee *big.Int
+	prePay      *big.Int
+}
+
+// rentFees calculates the prepaid fee of renting the stake for the prepay
+// period in seconds. Everything is calculated in SUN & rounded up, so that
+// the call value never falls short of what the contract charges.
+func rentFees(p *rentParams, prepay int64) fees {
+	rent := rentOf(p, prepay)
+	rent.Add(rent, p.threshold)
+
+	curFee := tron.CeilDiv(new(big.Int).Mul(p.stake, p.ratio), scale18)
+	fee := curFee
+	if p.minFee.Cmp(curFee) > 0 {
+		fee = p.minFee
+	}
+	return fees{
+		rent:   rent,
+		curFee: curFee,
+		fee:    fee,
+		prePay: new(big.Int).Add(rent, fee),
 	}
-	newLiquidateThreshold := new(big.Int).SetBytes(result.GetConstantResult()[0]).Int64()
-	return decimal.NewFromInt(newLiquidateThreshold).Div(decimal.NewFromInt(1000000)), nil
 }
 
-func (ls *Service) feeRatio(ctx context.Context, address, privateKey string) (decimal.Decimal, error) {
-	data := []byte{}
-	methodId, _ := hexutil.Decode(justlend.FeeRatioABI)
-	data = append(data, methodId...)
-	data = append(data, common.LeftPadBytes(internal.DecodeCheck(address)[1:], 32)...)
-	data = append(data, common.LeftPadBytes(internal.DecodeCheck(justlend.JustLendContract)[1:], 32)...)
-	result, _, err := ls.tron.TriggerConstantContract(ctx, justlend.JustLendContract, data, privateKey, 0)
+// scale18 is the scale of the rental rate & the fee ratio.
+var scale18 = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// rentOf returns the rent in SUN of the stake over the duration in seconds,
+// rounded up.
+func rentOf(p *rentParams, d int64) *big.Int {
+	rent := new(big.Int).Mul(p.stake, p.rate)
+	return tron.CeilDiv(rent.Mul(rent, big.NewInt(d)), scale18)
+}
+
+// toTRX converts the SUN amount into TRX.
+func toTRX(sun *big.Int) decimal.Decimal {
+	return decimal.NewFromBigInt(sun, -6)
+}
+
+func (ls *Service) liquidateThreshold(ctx context.Context, address string) (decimal.Decimal, error) {
+	threshold, err := ls.rental.LiquidateThreshold(ctx, address)
 	if err != nil {
 		return decimal.Zero, err
 	}
-	feeRatioNotPrecision := new(big.Int).SetBytes(result.GetConstantResult()[0]).Int64()
-	return decimal.NewFromInt(feeRatioNotPrecision).Div(decimal.NewFromUint64(justlend.TokenDefaultPrecision)), nil
+	// Convert SUN into TRX.
+	return decimal.NewFromBigInt(threshold, -6), nil
 }
@@ -0,0 +1,383 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/config"
+	"justlend/internal/database"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/log"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"strings"
+	"time"
+)
+
+//go:embed schema.sql
+var schema string
+
+// quoteRetention is how long the quotes are kept in the ledger.
+var quoteRetention = config.GetEnvDuration("QUOTE_RETENTION", 7*24*3600) * time.Second
+
+// Migrate creates the tables of the ledger if they don't exist.
+func Migrate(ctx context.Context, db *database.DB) error {
+	return db.Migrate(ctx, schema)
+}
+
+// now returns the current time in the precision kept by both databases.
+func now() time.Time { return time.Now().UTC().Truncate(time.Microsecond) }
+
+// createOrder records the new order, its ID & timestamps are populated.
+func (ls *Service) createOrder(ctx context.Context, o *justlend.OrderRL) error {
+	o.Id = uuid.NewString()
+	o.Status = justlend.OrderCreated
+	o.CreatedAt = now()
+	o.UpdatedAt = o.CreatedAt
+
+	var receiver *internal.Address
+	if !internal.IsEmpty(o.Receiver) {
+		a := internal.Address(internal.DecodeCheck(o.Receiver))
+		receiver = &a
+	}
+	// The unsigned transactions are built before their orders are created.
+	var expiresAt sql.NullTime
+	if o.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: o.ExpiresAt.UTC(), Valid: true}
+	}
+	return ls.db.Transact(ctx, nil, func(tx *database.DB) error {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO orders (id, kind, status, owner, receiver, resource_type,
+				amount, stake_per_trx, fee, tx_id, expires_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+			o.Id, o.Kind, o.Status, internal.Address(internal.DecodeCheck(o.Owner)), receiver,
+			int32(o.Type), o.Amount, o.StakePerTrx, o.Fee, o.TxId, expiresAt, o.CreatedAt, o.UpdatedAt); err != nil {
+			return err
+		}
+		return insertOrderEvent(ctx, tx, o.Id, o.Status, o.TxId, "", o.CreatedAt)
+	})
+}
+
+func insertOrderEvent(ctx context.Context, tx *database.DB,
+	id string,
+	status justlend.OrderStatus,
+	txId, reason string,
+	at time.Time) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO order_events (order_id, status, tx_id, error, created_at)
+		VALUES ($1, $2, $3, $4, $5)`, id, status, txId, reason, at)
+	return err
+}
+
+// orderChange holds the fields changed along with a transition, the empty
+// fields are left unchanged.
+type orderChange struct {
+	TxId      string
+	ExpiresAt time.Time
+	Error     string
+}
+
+// transition moves the order into the status, it fails with derrors.NotFound
+// if there's no such order and derrors.InconsistentData if the status of the
+// order doesn't allow the transition.
+func (ls *Service) transition(ctx context.Context, id string, to justlend.OrderStatus, c orderChange) (err error) {
+	defer derrors.Wrap(&err, "transition(%q, %q)", id, to)
+
+	var expiresAt sql.NullTime
+	if !c.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: c.ExpiresAt.UTC(), Valid: true}
+	}
+	return ls.db.Transact(ctx, nil, func(tx *database.DB) error {
+		var from justlend.OrderStatus
+		err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1`, id).Scan(&from)
+		if errors.Is(err, sql.ErrNoRows) {
+			return derrors.NotFound
+		} else if err != nil {
+			return err
+		} else if !from.CanTransition(to) {
+			return derrors.InconsistentData
+		}
+		at := now()
+		n, err := tx.Exec(ctx, `
+			UPDATE orders SET
+				status = $1,
+				tx_id = CASE WHEN $2 = '' THEN tx_id ELSE $2 END,
+				expires_at = COALESCE($3, expires_at),
+				error = $4,
+				updated_at = $5
+			WHERE id = $6 AND status = $7`,
+			to, c.TxId, expiresAt, c.Error, at, id, from)
+		if err != nil {
+			return err
+		} else if n == 0 {
+			// Moved by a concurrent transition.
+			return derrors.InconsistentData
+		}
+		return insertOrderEvent(ctx, tx, id, to, c.TxId, c.Error, at)
+	})
+}
+
+// logTransition is like transition, but only logs the failure, it's used
+// once the transaction is broadcast, since the txID must be returned to the
+// caller even if the ledger fails.
+func (ls *Service) logTransition(ctx context.Context, id string, to justlend.OrderStatus, c orderChange) {
+	if err := ls.transition(ctx, id, to, c); err != nil {
+		log.ErrorW("fails to record order transition", "order", id, "status", to, "txId", c.TxId, "error", err)
+	}
+}
+
+// settle moves the broadcast order along with the receipt of its transaction,
+// nothing is done until the receipt is final.
+func (ls *Service) settle(ctx context.Context, o *justlend.OrderRL, r *justlend.TransactionRL) {
+	switch tron.TxStatus(r.Status) {
+	case tron.TxConfirmed:
+		ls.logTransition(ctx, o.Id, justlend.OrderConfirmed, orderChange{})
+		if o.Kind == justlend.OrderReturn {
+			ls.markReturned(ctx, o)
+		}
+	case tron.TxReverted:
+		reason := r.RevertReason
+		if internal.IsEmpty(reason) {
+			reason = r.ContractResult
+		}
+		ls.logTransition(ctx, o.Id, justlend.OrderFailed, orderChange{Error: reason})
+	case tron.TxExpired:
+		ls.logTransition(ctx, o.Id, justlend.OrderFailed, orderChange{Error: string(tron.TxExpired)})
+	}
+}
+
+// markReturned moves the confirmed rent orders of the returned rental into
+// returned, once the rental is fully returned on chain.
+func (ls *Service) markReturned(ctx context.Context, o *justlend.OrderRL) {
+	rental, err := ls.rentalOf(ctx, o.Owner, o.Receiver, o.Type, decimal.Zero)
+	if err != nil {
+		log.ErrorW("fails to query returned rental", "order", o.Id, "error", err)
+		return
+	} else if rental != nil {
+		// Partially returned.
+		return
+	}
+	rents, _, err := ls.findOrders(ctx, &justlend.OrdersMeta{
+		Owner:    o.Owner,
+		Receiver: o.Receiver,
+		Kind:     justlend.OrderRent,
+		Status:   justlend.OrderConfirmed,
+	}, access{}, false)
+	if err != nil {
+		log.ErrorW("fails to find returned orders", "order", o.Id, "error", err)
+		return
+	}
+	for _, r := range rents {
+		if r.Type == o.Type {
+			ls.logTransition(ctx, r.Id, justlend.OrderReturned, orderChange{})
+		}
+	}
+}
+
+// Reconcile settles the broadcast orders whose transactions are final, so
+// that the orders not waited by the requests are settled eventually. The
+// unsigned transactions never broadcast fail once they expire, & the quotes
+// out of the retention window are pruned.
+func (ls *Service) Reconcile(ctx context.Context) (err error) {
+	defer derrors.WrapStack(&err, "ls.Reconcile()")
+
+	if _, err = ls.db.Exec(ctx, `DELETE FROM quotes WHERE created_at < $1`, now().Add(-quoteRetention)); err != nil {
+		return err
+	}
+	// The quote orders of the former ledgers stay created without a
+	// transaction for good, so only the expired unsigned transactions are
+	// selected rather than every created order.
+	var expired []string
+	if err = ls.db.RunQuery(ctx, `
+		SELECT id FROM orders
+		WHERE status = $1 AND kind <> $2 AND tx_id <> '' AND expires_at < $3`, func(rows *sql.Rows) error {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		expired = append(expired, id)
+		return nil
+	}, justlend.OrderCreated, justlend.OrderQuote, now()); err != nil {
+		return err
+	}
+	for _, id := range expired {
+		ls.logTransition(ctx, id, justlend.OrderFailed, orderChange{Error: string(tron.TxExpired)})
+	}
+
+	orders, _, err := ls.findOrders(ctx, &justlend.OrdersMeta{Status: justlend.OrderBroadcast}, access{}, false)
+	if err != nil {
+		return err
+	}
+	for _, o := range orders {
+		var expiration time.Time
+		if o.ExpiresAt != nil {
+			expiration = *o.ExpiresAt
+		}
+		r, err := ls.tron.TransactionStatus(ctx, o.TxId, expiration)
+		if err != nil {
+			log.WarnW("fails to check order transaction", "order", o.Id, "txId", o.TxId, "error", err)
+			continue
+		}
+		ls.settle(ctx, o, toTransactionRL(r))
+	}
+	return nil
+}
+
+func (ls *Service) Orders(ctx context.Context,
+	req *justlend.OrdersMeta) (_ []*justlend.OrderRL, _ int, err error) {
+	defer derrors.WrapStack(&err, "ls.Orders()")
+
+	return ls.findOrders(ctx, req, ls.accessOf(ctx), true)
+}
+
+func (ls *Service) Order(ctx context.Context,
+	req *justlend.OrderMeta) (_ *justlend.OrderRL, err error) {
+	defer derrors.WrapStack(&err, "ls.Order()")
+
+	return ls.getVisibleOrder(ctx, req.Id)
+}
+
+func (ls *Service) OrderEvents(ctx context.Context,
+	req *justlend.OrderMeta) (_ []*justlend.OrderEventRL, _ int, err error) {
+	defer derrors.WrapStack(&err, "ls.OrderEvents()")
+
+	// Distinguish the unknown orders from the orders without events.
+	if _, err = ls.getVisibleOrder(ctx, req.Id); err != nil {
+		return nil, 0, err
+	}
+	var events []*justlend.OrderEventRL
+	err = ls.db.RunQuery(ctx, `
+		SELECT status, tx_id, error, created_at FROM order_events
+		WHERE order_id = $1 ORDER BY created_at`, func(rows *sql.Rows) error {
+		e := new(justlend.OrderEventRL)
+		if err := rows.Scan(&e.Status, &e.TxId, &e.Error, &e.CreatedAt); err != nil {
+			return err
+		}
+		events = append(events, e)
+		return nil
+	}, req.Id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, len(events), nil
+}
+
+const orderColumns = `id, kind, status, owner, receiver, resource_type, amount,
+	stake_per_trx, fee, tx_id, expires_at, error, created_at, updated_at`
+
+func scanOrder(s interface{ Scan(...any) error }) (*justlend.OrderRL, error) {
+	var (
+		o         justlend.OrderRL
+		owner     internal.Address
+		receiver  *internal.Address
+		rt        int32
+		expiresAt sql.NullTime
+	)
+	if err := s.Scan(&o.Id, &o.Kind, &o.Status, &owner, &receiver, &rt, &o.Amount,
+		&o.StakePerTrx, &o.Fee, &o.TxId, &expiresAt, &o.Error, &o.CreatedAt, &o.UpdatedAt); err != nil {
+		return nil, err
+	}
+	o.Owner = owner.String()
+	if receiver != nil {
+		o.Receiver = receiver.String()
+	}
+	o.Type = core.ResourceCode(rt)
+	if expiresAt.Valid {
+		o.ExpiresAt = &expiresAt.Time
+	}
+	return &o, nil
+}
+
+func (ls *Service) getOrder(ctx context.Context, id string) (*justlend.OrderRL, error) {
+	o, err := scanOrder(ls.db.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, derrors.NotFound
+	}
+	return o, err
+}
+
+// getVisibleOrder is like getOrder, the orders hidden from the API key of the
+// request are not found.
+func (ls *Service) getVisibleOrder(ctx context.Context, id string) (*justlend.OrderRL, error) {
+	o, err := ls.getOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !ls.accessOf(ctx).allows(o) {
+		return nil, derrors.NotFound
+	}
+	return o, nil
+}
+
+// findOrders returns the orders matching the filter & visible to the access,
+// the latest first, the page & the total count of the filter are only applied
+// if paged.
+func (ls *Service) findOrders(ctx context.Context,
+	req *justlend.OrdersMeta,
+	a access,
+	paged bool) ([]*justlend.OrderRL, int, error) {
+
+	var (
+		conds []string
+		args  []any
+	)
+	where := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if !internal.IsEmpty(req.Owner) {
+		where("owner = $%d", internal.Address(internal.DecodeCheck(req.Owner)))
+	}
+	if !internal.IsEmpty(req.Receiver) {
+		where("receiver = $%d", internal.Address(internal.DecodeCheck(req.Receiver)))
+	}
+	if req.Kind != "" {
+		where("kind = $%d", req.Kind)
+	}
+	if req.Status != "" {
+		where("status = $%d", req.Status)
+	}
+	if !internal.IsEmpty(req.TxId) {
+		where("tx_id = $%d", req.TxId)
+	}
+	conds, args = a.where("owner", "receiver", conds, args)
+	filter := ""
+	if len(conds) > 0 {
+		filter = " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders` + filter + ` ORDER BY created_at DESC, id`
+	var total int
+	if paged {
+		if err := ls.db.QueryRow(ctx, `SELECT COUNT(*) FROM orders`+filter, args...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+		query += limitOffset(req.Limit, req.Offset)
+	}
+	var orders []*justlend.OrderRL
+	err := ls.db.RunQuery(ctx, query, func(rows *sql.Rows) error {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return err
+		}
+		orders = append(orders, o)
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !paged {
+		total = len(orders)
+	}
+	return orders, total, nil
+}
+
+// limitOffset returns the clause selecting the page.
+func limitOffset(limit, offset uint64) string {
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+}
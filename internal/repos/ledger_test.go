@@ -0,0 +1,105 @@
+package repos
+
+import (
+	"context"
+	"justlend/internal/database"
+	"justlend/internal/justlend"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron/tronfake"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const owner = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+
+// newLedgerService is like newFakeService, but it records the orders into a
+// fresh SQLite ledger.
+func newLedgerService(t *testing.T) (*Service, *tronfake.Server) {
+	t.Helper()
+	ls, node := newFakeService(t)
+	db, err := database.Open("sqlite", filepath.Join(t.TempDir(), "justlend.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err = Migrate(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+	ls.db = db
+	return ls, node
+}
+
+func TestReconcileExpired(t *testing.T) {
+	ls, _ := newLedgerService(t)
+	ctx := context.Background()
+
+	past, future := now().Add(-time.Minute), now().Add(time.Hour)
+	orders := []struct {
+		o    justlend.OrderRL
+		want justlend.OrderStatus
+	}{
+		{justlend.OrderRL{Kind: justlend.OrderQuote}, justlend.OrderCreated},
+		{justlend.OrderRL{Kind: justlend.OrderRent, TxId: "aa", ExpiresAt: &past}, justlend.OrderFailed},
+		{justlend.OrderRL{Kind: justlend.OrderReturn, TxId: "bb", ExpiresAt: &future}, justlend.OrderCreated},
+	}
+	for i := range orders {
+		o := &orders[i].o
+		o.Owner, o.Type = owner, core.ResourceCode_ENERGY
+		if err := ls.createOrder(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ls.Reconcile(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range orders {
+		o, err := ls.getOrder(ctx, tt.o.Id)
+		if err != nil {
+			t.Fatal(err)
+		} else if o.Status != tt.want {
+			t.Errorf("%s order %q = %s, want %s", o.Kind, o.TxId, o.Status, tt.want)
+		}
+	}
+}
+
+func TestRecordQuote(t *testing.T) {
+	ls, _ := newLedgerService(t)
+	ctx := context.Background()
+	req := &justlend.FeeRatioMeta{Energy: 65000, Type: core.ResourceCode_ENERGY}
+	quotes := func() (n int) {
+		t.Helper()
+		if err := ls.db.QueryRow(ctx, `SELECT COUNT(*) FROM quotes`).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	rl, err := ls.FeeRatio(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	} else if rl.OrderId == "" || quotes() != 1 {
+		t.Fatalf("quote %q recorded %d times, want once", rl.OrderId, quotes())
+	}
+	if _, n, err := ls.Orders(ctx, &justlend.OrdersMeta{Limit: 10}); err != nil || n != 0 {
+		t.Errorf("quote recorded %d orders, %v", n, err)
+	}
+
+	// The quotes are pruned out of the retention window.
+	if _, err = ls.db.Exec(ctx, `UPDATE quotes SET created_at = $1`, now().Add(-quoteRetention-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err = ls.Reconcile(ctx); err != nil {
+		t.Fatal(err)
+	} else if n := quotes(); n != 0 {
+		t.Errorf("%d quotes left after the retention window", n)
+	}
+
+	// A quote is served even if the ledger fails.
+	if _, err = ls.db.Exec(ctx, `DROP TABLE quotes`); err != nil {
+		t.Fatal(err)
+	}
+	if rl, err = ls.FeeRatio(ctx, req); err != nil || rl.OrderId != "" {
+		t.Errorf("quote without a ledger = %v, %v", rl, err)
+	}
+}
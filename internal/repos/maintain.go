@@ -0,0 +1,252 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/log"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+)
+
+// errBudgetExhausted is recorded in the policies whose next rental would
+// exceed the budget.
+var errBudgetExhausted = fmt.Errorf("%w: budget exhausted", derrors.Forbidden)
+
+func (ls *Service) CreateMaintain(ctx context.Context,
+	req *justlend.MaintainPolicyMeta) (_ *justlend.MaintainPolicyRL, err error) {
+	defer derrors.WrapStack(&err, "ls.CreateMaintain()")
+
+	if _, _, err = ls.payer(req.Wallet, ""); err != nil {
+		return nil, err
+	}
+	id, at := uuid.NewString(), now()
+	if _, err = ls.db.Exec(ctx, `
+		INSERT INTO maintain_policies (id, receiver, wallet, target, tolerance,
+			max_spend, paused, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		id, internal.Address(internal.DecodeCheck(req.Receiver)), req.Wallet, req.Target,
+		req.Tolerance, req.MaxSpend, req.Paused, at, at); err != nil {
+		return nil, err
+	}
+	return ls.getMaintain(ctx, id)
+}
+
+func (ls *Service) Maintains(ctx context.Context,
+	req *justlend.MaintainsMeta) (_ []*justlend.MaintainPolicyRL, _ int, err error) {
+	defer derrors.WrapStack(&err, "ls.Maintains()")
+
+	var total int
+	if err = ls.db.QueryRow(ctx, `SELECT COUNT(*) FROM maintain_policies`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	ps, err := ls.findMaintains(ctx, ` ORDER BY created_at, id`+limitOffset(req.Limit, req.Offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	return ps, total, nil
+}
+
+func (ls *Service) GetMaintain(ctx context.Context,
+	req *justlend.MaintainMeta) (_ *justlend.MaintainPolicyRL, err error) {
+	defer derrors.WrapStack(&err, "ls.GetMaintain()")
+
+	return ls.getMaintain(ctx, req.Id)
+}
+
+func (ls *Service) UpdateMaintain(ctx context.Context,
+	req *justlend.UpdateMaintainMeta) (_ *justlend.MaintainPolicyRL, err error) {
+	defer derrors.WrapStack(&err, "ls.UpdateMaintain()")
+
+	if _, _, err = ls.payer(req.Wallet, ""); err != nil {
+		return nil, err
+	}
+	n, err := ls.db.Exec(ctx, `
+		UPDATE maintain_policies SET receiver = $1, wallet = $2, target = $3,
+			tolerance = $4, max_spend = $5, paused = $6, updated_at = $7
+		WHERE id = $8`,
+		internal.Address(internal.DecodeCheck(req.Receiver)), req.Wallet, req.Target,
+		req.Tolerance, req.MaxSpend, req.Paused, now(), req.Id)
+	if err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, derrors.NotFound
+	}
+	return ls.getMaintain(ctx, req.Id)
+}
+
+func (ls *Service) DeleteMaintain(ctx context.Context, req *justlend.MaintainMeta) (err error) {
+	defer derrors.WrapStack(&err, "ls.DeleteMaintain()")
+
+	n, err := ls.db.Exec(ctx, `DELETE FROM maintain_policies WHERE id = $1`, req.Id)
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return derrors.NotFound
+	}
+	return nil
+}
+
+// Maintain checks the policies one by one, the outcome of each check is
+// recorded in the policy.
+func (ls *Service) Maintain(ctx context.Context) (err error) {
+	defer derrors.WrapStack(&err, "ls.Maintain()")
+
+	ps, err := ls.findMaintains(ctx, ` WHERE paused = $1`, false)
+	if err != nil {
+		return err
+	}
+	for _, p := range ps {
+		if ctx.Err() != nil {
+			return nil
+		}
+		available, orderId, fee, err := ls.keepEnergy(ctx, p)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+			log.WarnW("fails to maintain energy", "policy", p.Id, "receiver", p.Receiver, "error", err)
+		}
+		if orderId == "" {
+			orderId = p.LastOrderId
+		}
+		if _, err = ls.db.Exec(context.WithoutCancel(ctx), `
+			UPDATE maintain_policies SET available = $1, checked_at = $2,
+				last_order_id = $3, spent = spent + $4, error = $5
+			WHERE id = $6`,
+			available, now(), orderId, fee, reason, p.Id); err != nil {
+			log.ErrorW("fails to record maintained energy", "policy", p.Id, "error", err)
+		}
+	}
+	return nil
+}
+
+// keepEnergy rents the shortfall below the target or returns the surplus
+// above the tolerance, it reports the available energy along with the order
+// & the prepaid fee of the rental if any. Nothing is done until the last
+// order of the policy is settled, since the energy is not delegated yet.
+func (ls *Service) keepEnergy(ctx context.Context, p *justlend.MaintainPolicyRL) (int64, string, int64, error) {
+	res, err := ls.tron.GetAccountResource(ctx, p.Receiver)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	available := res.GetEnergyLimit() - res.GetEnergyUsed()
+	if available < 0 {
+		available = 0
+	}
+	if p.LastOrderId != "" {
+		o, err := ls.getOrder(ctx, p.LastOrderId)
+		if err != nil && !errors.Is(err, derrors.NotFound) {
+			return available, "", 0, err
+		} else if err == nil && internal.Contains(o.Status, justlend.OrderCreated, justlend.OrderBroadcast) {
+			return available, "", 0, nil
+		}
+	}
+
+	switch {
+	case available < p.Target:
+		shortfall := p.Target - available
+		quote, err := ls.quote(ctx, &justlend.FeeRatioMeta{Energy: shortfall, Type: core.ResourceCode_ENERGY})
+		if err != nil {
+			return available, "", 0, err
+		} else if p.Spent+tron.ToSUN(quote.PrePayFee.Decimal) > p.MaxSpend {
+			return available, "", 0, errBudgetExhausted
+		}
+		rl, err := ls.RentResource(ctx, &justlend.RentResourceMeta{
+			Receive: p.Receiver,
+			Type:    core.ResourceCode_ENERGY,
+			Amount:  shortfall,
+			Wallet:  p.Wallet,
+		})
+		if err != nil {
+			return available, "", 0, err
+		}
+		// Charge the fee of the rental, which is quoted again by the rent.
+		o, err := ls.getOrder(ctx, rl.OrderId)
+		if err != nil {
+			return available, rl.OrderId, 0, err
+		}
+		return available, rl.OrderId, o.Fee, nil
+	case available > p.Target+p.Tolerance:
+		orderId, err := ls.returnSurplus(ctx, p, available-p.Target)
+		return available, orderId, 0, err
+	default:
+		return available, "", 0, nil
+	}
+}
+
+// returnSurplus returns the stake of the surplus energy, up to what the
+// wallet rents to the receiver.
+func (ls *Service) returnSurplus(ctx context.Context, p *justlend.MaintainPolicyRL, surplus int64) (string, error) {
+	_, owner, err := ls.payer(p.Wallet, "")
+	if err != nil {
+		return "", err
+	}
+	rental, err := ls.rentalOf(ctx, owner, p.Receiver, core.ResourceCode_ENERGY, decimal.Zero)
+	if err != nil || rental == nil {
+		return "", err
+	}
+	// The stake is rounded up to TRX, one TRX less is returned so that the
+	// energy stays above the target.
+	trx, err := ls.tron.CalStackEnergy(ctx, owner, surplus, core.ResourceCode_ENERGY, false)
+	if err != nil {
+		return "", err
+	}
+	stakePerTrx := (trx - 1) * tron.SUNPerTRX
+	if stakePerTrx > rental.StakePerTrx {
+		stakePerTrx = rental.StakePerTrx
+	}
+	if stakePerTrx <= 0 {
+		return "", nil
+	}
+	rl, err := ls.ReturnResource(ctx, &justlend.ReturnResourceMeta{
+		Receive:     p.Receiver,
+		Type:        core.ResourceCode_ENERGY,
+		StakePerTrx: stakePerTrx,
+		Wallet:      p.Wallet,
+	})
+	if err != nil {
+		return "", err
+	}
+	return rl.OrderId, nil
+}
+
+const maintainColumns = `id, receiver, wallet, target, tolerance, max_spend, spent, paused,
+	available, checked_at, last_order_id, error, created_at, updated_at`
+
+func (ls *Service) getMaintain(ctx context.Context, id string) (*justlend.MaintainPolicyRL, error) {
+	ps, err := ls.findMaintains(ctx, ` WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	} else if len(ps) == 0 {
+		return nil, derrors.NotFound
+	}
+	return ps[0], nil
+}
+
+func (ls *Service) findMaintains(ctx context.Context, clauses string, args ...any) ([]*justlend.MaintainPolicyRL, error) {
+	var ps []*justlend.MaintainPolicyRL
+	err := ls.db.RunQuery(ctx, `SELECT `+maintainColumns+` FROM maintain_policies`+clauses, func(rows *sql.Rows) error {
+		var (
+			p         justlend.MaintainPolicyRL
+			receiver  internal.Address
+			checkedAt sql.NullTime
+		)
+		if err := rows.Scan(&p.Id, &receiver, &p.Wallet, &p.Target, &p.Tolerance, &p.MaxSpend, &p.Spent,
+			&p.Paused, &p.Available, &checkedAt, &p.LastOrderId, &p.Error, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return err
+		}
+		p.Receiver = receiver.String()
+		if checkedAt.Valid {
+			p.CheckedAt = &checkedAt.Time
+		}
+		ps = append(ps, &p)
+		return nil
+	}, args...)
+	return ps, err
+}
@@ -0,0 +1,25 @@
+package repos
+
+import (
+	"context"
+	"justlend/internal/justlend"
+)
+
+func (ls *Service) Nodes(_ context.Context) ([]*justlend.NodeRL, error) {
+	nodes := ls.tron.Nodes()
+	rl := make([]*justlend.NodeRL, 0, len(nodes))
+	for _, n := range nodes {
+		rl = append(rl, &justlend.NodeRL{
+			Addr:        n.Addr,
+			Healthy:     n.Healthy,
+			BlockHeight: n.BlockHeight,
+			Lag:         n.Lag,
+			Latency:     n.Latency,
+			Version:     n.Version,
+			Failures:    n.Failures,
+			Error:       n.Error,
+			CheckedAt:   n.CheckedAt,
+		})
+	}
+	return rl, nil
+}
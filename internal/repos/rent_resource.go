@@ -2,51 +2,84 @@ package repos
 
 import (
 	"context"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"justlend/internal"
 	"justlend/internal/derrors"
 	"justlend/internal/justlend"
+	"justlend/internal/log"
 	"justlend/internal/tron"
 	"math/big"
+	"time"
 )
 
 func (ls *Service) RentResource(ctx context.Context,
 	req *justlend.RentResourceMeta) (_ *justlend.RentResourceRL, err error) {
 	defer derrors.WrapStack(&err, "ls.RentResource()")
 
-	fee, err := ls.FeeRatio(ctx, &justlend.FeeRatioMeta{
-		PrivateKey: req.PrivateKey,
-		Type:       req.Type,
-		Energy:     req.Amount,
+	// The key of an unsigned transaction is kept offline, a dry run is only
+	// simulated & needs no key at all.
+	var signer tron.Signer
+	owner := req.Owner
+	if !req.Unsigned && !req.DryRun {
+		if signer, owner, err = ls.payer(req.Wallet, req.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+	fee, err := ls.quote(ctx, &justlend.FeeRatioMeta{
+		Owner:  owner,
+		Type:   req.Type,
+		Energy: req.Amount,
+		Prepay: req.Prepay,
 	})
+	if err != nil {
+		return nil, err
+	}
+	stakePerTrx := fee.StakePerTrx * tron.SUNPerTRX
 
-	stakePerTrx := tron.ToSUN(float64(fee.StakePerTrx))
-
-	data := []byte{}
-	methodId, _ := hexutil.Decode(justlend.RentResourceABI)
-	paddedReceive := common.LeftPadBytes(internal.DecodeCheck(req.Receive)[1:], 32)
-	paddedAmount := common.LeftPadBytes(new(big.Int).SetInt64(stakePerTrx).Bytes(), 32)
-	paddedResourceType := common.LeftPadBytes(new(big.Int).SetInt64(int64(req.Type)).Bytes(), 32)
-	data = append(data, methodId...)
-	data = append(data, paddedReceive...)
-	data = append(data, paddedAmount...)
-	data = append(data, paddedResourceType...)
-	result, txId, err := ls.tron.TriggerConstantContract(
-		ctx,
-		justlend.JustLendContract,
-		data,
-		req.PrivateKey,
-		tron.ToSUN(fee.PrePayFee),
-	)
+	data, err := ls.rental.PackRentResource(req.Receive, big.NewInt(stakePerTrx), req.Type)
 	if err != nil {
 		return nil, err
 	}
-	if _, err = ls.tron.BroadcastTransaction(ctx, result.Transaction); err != nil {
+	if req.DryRun {
+		dr, err := ls.dryRun(ctx, owner, justlend.JustLendContract, data, tron.ToSUN(fee.PrePayFee.Decimal))
+		if err != nil {
+			return nil, err
+		}
+		return &justlend.RentResourceRL{StakePerTrx: stakePerTrx, DryRun: dr}, nil
+	}
+	o := &justlend.OrderRL{
+		Kind:        justlend.OrderRent,
+		Owner:       owner,
+		Receiver:    req.Receive,
+		Type:        req.Type,
+		Amount:      req.Amount,
+		StakePerTrx: stakePerTrx,
+		Fee:         tron.ToSUN(fee.PrePayFee.Decimal),
+	}
+	if req.Unsigned {
+		tx, err := ls.export(ctx, o, data, o.Fee)
+		if err != nil {
+			return nil, err
+		}
+		return &justlend.RentResourceRL{OrderId: o.Id, TxId: o.TxId, StakePerTrx: stakePerTrx, Unsigned: tx}, nil
+	}
+	receipt, err := ls.execute(ctx, o, signer, data, o.Fee, req.Wait)
+	if err != nil {
 		return nil, err
 	}
-	return &justlend.RentResourceRL{
-		TxId:        txId,
+	rl := &justlend.RentResourceRL{
+		OrderId:     o.Id,
+		TxId:        o.TxId,
 		StakePerTrx: stakePerTrx,
-	}, nil
+		Receipt:     receipt,
+	}
+	if req.Duration > 0 {
+		// The rent is already broadcast, the failure is logged rather than
+		// returned to keep the txID, the caller finds no returnAt then.
+		returnAt, err := ls.scheduleReturn(ctx, o, req.Wallet, time.Duration(req.Duration)*time.Second)
+		if err != nil {
+			log.ErrorW("fails to schedule return", "order", o.Id, "error", err)
+		} else {
+			rl.ReturnAt = &returnAt
+		}
+	}
+	return rl, nil
 }
@@ -0,0 +1,65 @@
+package repos
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/protos/core"
+)
+
+func (ls *Service) Rentals(ctx context.Context,
+	req *justlend.RentalMeta) (_ []*justlend.RentalRL, err error) {
+	defer derrors.WrapStack(&err, "ls.Rentals()")
+
+	types := []core.ResourceCode{core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY}
+	if req.Type != nil {
+		types = []core.ResourceCode{*req.Type}
+	}
+	threshold, err := ls.liquidateThreshold(ctx, req.Renter)
+	if err != nil {
+		return nil, err
+	}
+	rls := make([]*justlend.RentalRL, 0, len(types))
+	for _, rt := range types {
+		rl, err := ls.rentalOf(ctx, req.Renter, req.Receiver, rt, threshold)
+		if err != nil {
+			return nil, err
+		} else if rl != nil {
+			rls = append(rls, rl)
+		}
+	}
+	return rls, nil
+}
+
+// rentalOf returns the rental of the resource from the renter to the
+// receiver, a nil rental returned if there's no such rental.
+func (ls *Service) rentalOf(ctx context.Context,
+	renter, receiver string,
+	rt core.ResourceCode,
+	threshold decimal.Decimal) (*justlend.RentalRL, error) {
+
+	info, err := ls.rental.GetRentInfo(ctx, renter, receiver, rt)
+	if err != nil {
+		return nil, err
+	} else if info.Amount.Sign() == 0 {
+		return nil, nil
+	} else if !info.Amount.IsInt64() {
+		return nil, derrors.InconsistentData
+	}
+	// Convert SUN into TRX.
+	deposit := decimal.NewFromBigInt(info.SecurityDeposit, -6)
+	accrued := decimal.NewFromBigInt(info.AccruedFee, -6)
+	remaining := deposit.Sub(accrued)
+	return &justlend.RentalRL{
+		Renter:             renter,
+		Receiver:           receiver,
+		Type:               rt,
+		StakePerTrx:        info.Amount.Int64(),
+		SecurityDeposit:    deposit,
+		AccruedFee:         accrued,
+		RemainingDeposit:   remaining,
+		LiquidateThreshold: threshold,
+		Liquidatable:       remaining.LessThan(threshold),
+	}, nil
+}
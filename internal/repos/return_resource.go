@@ -2,11 +2,10 @@ package repos
 
 import (
 	"context"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"justlend/internal"
+	"github.com/shopspring/decimal"
 	"justlend/internal/derrors"
 	"justlend/internal/justlend"
+	"justlend/internal/tron"
 	"math/big"
 )
 
@@ -15,26 +14,63 @@ func (ls *Service) ReturnResource(ctx context.Context,
 
 	defer derrors.WrapStack(&err, "ls.ReturnResource()")
 
-	data := []byte{}
-	methodId, _ := hexutil.Decode(justlend.ReturnResourceABI)
-	data = append(data, methodId...)
-	data = append(data, common.LeftPadBytes(internal.DecodeCheck(req.Receive)[1:], 32)...)
-	data = append(data, common.LeftPadBytes(new(big.Int).SetInt64(req.StakePerTrx).Bytes(), 32)...)
-	data = append(data, common.LeftPadBytes(new(big.Int).SetInt64(int64(req.Type)).Bytes(), 32)...)
-	result, txId, err := ls.tron.TriggerConstantContract(
-		ctx,
-		justlend.JustLendContract,
-		data,
-		req.PrivateKey,
-		0,
-	)
+	// The key of an unsigned transaction is kept offline, a dry run is only
+	// simulated & needs no key at all.
+	var signer tron.Signer
+	owner := req.Owner
+	if !req.Unsigned && !req.DryRun {
+		if signer, owner, err = ls.payer(req.Wallet, req.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return the full outstanding amount of the rental if the amount
+	// is not specified.
+	stakePerTrx := req.StakePerTrx
+	if stakePerTrx == 0 {
+		rental, err := ls.rentalOf(ctx, owner, req.Receive, req.Type, decimal.Zero)
+		if err != nil {
+			return nil, err
+		} else if rental == nil {
+			return nil, derrors.NotFound
+		}
+		stakePerTrx = rental.StakePerTrx
+	}
+
+	data, err := ls.rental.PackReturnResource(req.Receive, big.NewInt(stakePerTrx), req.Type)
 	if err != nil {
 		return nil, err
 	}
-	if _, err = ls.tron.BroadcastTransaction(ctx, result.Transaction); err != nil {
+	if req.DryRun {
+		dr, err := ls.dryRun(ctx, owner, justlend.JustLendContract, data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &justlend.ReturnResourceRL{StakePerTrx: stakePerTrx, DryRun: dr}, nil
+	}
+	o := &justlend.OrderRL{
+		Kind:        justlend.OrderReturn,
+		Owner:       owner,
+		Receiver:    req.Receive,
+		Type:        req.Type,
+		StakePerTrx: stakePerTrx,
+	}
+	if req.Unsigned {
+		tx, err := ls.export(ctx, o, data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &justlend.ReturnResourceRL{OrderId: o.Id, TxId: o.TxId, StakePerTrx: stakePerTrx, Unsigned: tx}, nil
+	}
+	receipt, err := ls.execute(ctx, o, signer, data, 0, req.Wait)
+	if err != nil {
 		return nil, err
 	}
-	return &justlend.ReturnResourceRL{
-		TxId: txId,
-	}, nil
+	rl := &justlend.ReturnResourceRL{
+		OrderId:     o.Id,
+		TxId:        o.TxId,
+		StakePerTrx: stakePerTrx,
+		Receipt:     receipt,
+	}
+	return rl, nil
 }
@@ -0,0 +1,301 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/config"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/log"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"strings"
+	"time"
+)
+
+var (
+	// maxReturnAttempts is the number of the failed attempts after which a
+	// scheduled return is given up.
+	maxReturnAttempts = config.GetEnvInt("RETURN_MAX_ATTEMPTS", 10)
+	// returnBackoff is the delay before the first retry of a scheduled return,
+	// which is doubled on every failure up to maxReturnBackoff.
+	returnBackoff    = config.GetEnvDuration("RETURN_BACKOFF", 60) * time.Second
+	maxReturnBackoff = config.GetEnvDuration("RETURN_MAX_BACKOFF", 3600) * time.Second
+	// returnTimeout bounds each attempt, including the wait for the receipt.
+	returnTimeout = config.GetEnvDuration("RETURN_TIMEOUT", 60) * time.Second
+)
+
+// scheduleReturn schedules the return of the rent order once the duration
+// elapses, the return is signed by the wallet.
+func (ls *Service) scheduleReturn(ctx context.Context, o *justlend.OrderRL, wallet string, d time.Duration) (time.Time, error) {
+	at := now()
+	returnAt := at.Add(d)
+	_, err := ls.db.Exec(ctx, `
+		INSERT INTO return_schedules (order_id, wallet, status, return_at,
+			next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		o.Id, wallet, justlend.SchedulePending, returnAt, returnAt, at, at)
+	return returnAt, err
+}
+
+func (ls *Service) Schedules(ctx context.Context,
+	req *justlend.SchedulesMeta) (_ []*justlend.ScheduleRL, _ int, err error) {
+	defer derrors.WrapStack(&err, "ls.Schedules()")
+
+	var (
+		conds []string
+		args  []any
+	)
+	if req.Status != "" {
+		conds, args = append(conds, "s.status = $1"), append(args, req.Status)
+	}
+	conds, args = ls.accessOf(ctx).where("o.owner", "o.receiver", conds, args)
+	filter := ""
+	if len(conds) > 0 {
+		filter = " WHERE " + strings.Join(conds, " AND ")
+	}
+	var total int
+	if err = ls.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM return_schedules s JOIN orders o ON o.id = s.order_id`+filter,
+		args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	schedules, err := ls.findSchedules(ctx, filter+` ORDER BY s.return_at, s.order_id`+limitOffset(req.Limit, req.Offset), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return schedules, total, nil
+}
+
+func (ls *Service) CancelSchedule(ctx context.Context,
+	req *justlend.ScheduleMeta) (_ *justlend.ScheduleRL, err error) {
+	defer derrors.WrapStack(&err, "ls.CancelSchedule()")
+
+	// The schedules of the orders hidden from the API key are not found.
+	if _, err = ls.getVisibleOrder(ctx, req.OrderId); err != nil {
+		return nil, err
+	}
+	n, err := ls.db.Exec(ctx, `
+		UPDATE return_schedules SET status = $1, updated_at = $2
+		WHERE order_id = $3 AND status = $4`,
+		justlend.ScheduleCancelled, now(), req.OrderId, justlend.SchedulePending)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ls.getSchedule(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	} else if n == 0 {
+		// Only the pending returns may be cancelled.
+		return nil, derrors.InconsistentData
+	}
+	return s, nil
+}
+
+// ReturnDue attempts the due returns one by one, the failed attempts are
+// retried with an exponential backoff. The schedules are loaded from the
+// database on every call, so that they survive the restarts.
+func (ls *Service) ReturnDue(ctx context.Context) (err error) {
+	defer derrors.WrapStack(&err, "ls.ReturnDue()")
+
+	due, err := ls.findSchedules(ctx, `
+		WHERE s.status = $1 AND s.next_attempt_at <= $2
+		ORDER BY s.next_attempt_at`, justlend.SchedulePending, now())
+	if err != nil {
+		return err
+	}
+	for _, s := range due {
+		if ctx.Err() != nil {
+			return nil
+		}
+		ls.attemptReturn(ctx, s)
+	}
+	return nil
+}
+
+// attemptReturn makes an attempt of the scheduled return & records its outcome.
+func (ls *Service) attemptReturn(ctx context.Context, s *justlend.ScheduleRL) {
+	attemptCtx, cancel := context.WithTimeout(ctx, returnTimeout)
+	defer cancel()
+
+	status, returnOrderId, err := ls.tryReturn(attemptCtx, s)
+	switch {
+	case err != nil:
+		s.Attempts++
+		s.Error = err.Error()
+		s.NextAttemptAt = now().Add(backoff(s.Attempts))
+		if s.Attempts >= maxReturnAttempts {
+			status = justlend.ScheduleFailed
+		}
+		log.WarnW("scheduled return failed", "order", s.OrderId, "attempts", s.Attempts, "error", err)
+	case status == justlend.SchedulePending:
+		// The return is not final yet, check it again later.
+		s.NextAttemptAt = now().Add(returnBackoff)
+	default:
+		s.Error = ""
+	}
+	if returnOrderId != "" {
+		s.ReturnOrderId = returnOrderId
+	}
+	// The outcome is recorded even if the attempt timed out or the
+	// daemon is stopping, otherwise the return might be repeated.
+	if _, err = ls.db.Exec(context.WithoutCancel(ctx), `
+		UPDATE return_schedules SET
+			status = $1, attempts = $2, next_attempt_at = $3,
+			return_order_id = $4, error = $5, updated_at = $6
+		WHERE order_id = $7 AND status = $8`,
+		status, s.Attempts, s.NextAttemptAt, s.ReturnOrderId, s.Error, now(),
+		s.OrderId, justlend.SchedulePending); err != nil {
+		log.ErrorW("fails to record scheduled return", "order", s.OrderId, "error", err)
+	}
+}
+
+// tryReturn returns the rental of the schedule, it reports the new status of
+// the schedule along with the return order if a return is broadcast. The
+// previous return is awaited if it's not final, so that a rental is never
+// returned twice.
+func (ls *Service) tryReturn(ctx context.Context, s *justlend.ScheduleRL) (justlend.ScheduleStatus, string, error) {
+	if s.ReturnOrderId != "" {
+		prev, err := ls.getOrder(ctx, s.ReturnOrderId)
+		if err != nil {
+			return justlend.SchedulePending, "", err
+		}
+		switch prev.Status {
+		case justlend.OrderConfirmed:
+			return justlend.ScheduleDone, "", nil
+		case justlend.OrderCreated, justlend.OrderBroadcast:
+			return justlend.SchedulePending, "", nil
+		}
+		// The previous return failed, try again.
+	}
+
+	rent, err := ls.getOrder(ctx, s.OrderId)
+	if err != nil {
+		return justlend.SchedulePending, "", err
+	}
+	switch rent.Status {
+	case justlend.OrderFailed:
+		// Nothing was rented.
+		return justlend.ScheduleCancelled, "", nil
+	case justlend.OrderReturned:
+		return justlend.ScheduleDone, "", nil
+	case justlend.OrderCreated, justlend.OrderBroadcast:
+		// Wait for the rent to be settled by the reconciler.
+		return justlend.SchedulePending, "", nil
+	}
+
+	// Return what was rented by the order, unless less is left, i.e. the
+	// rental is returned partially by hand.
+	rental, err := ls.rentalOf(ctx, rent.Owner, rent.Receiver, rent.Type, decimal.Zero)
+	if err != nil {
+		return justlend.SchedulePending, "", err
+	} else if rental == nil {
+		return justlend.ScheduleDone, "", nil
+	}
+	amount := rent.StakePerTrx
+	if rental.StakePerTrx < amount {
+		amount = rental.StakePerTrx
+	}
+	// The last rent of the rental closes it, along with the stake rented
+	// by the top-ups of its deposit.
+	last, err := ls.lastRent(ctx, rent)
+	if err != nil {
+		return justlend.SchedulePending, "", err
+	} else if last {
+		amount = rental.StakePerTrx
+	}
+	rl, err := ls.ReturnResource(ctx, &justlend.ReturnResourceMeta{
+		Receive:     rent.Receiver,
+		Type:        rent.Type,
+		StakePerTrx: amount,
+		Wallet:      s.Wallet,
+		Wait:        true,
+	})
+	if err != nil {
+		return justlend.SchedulePending, "", err
+	}
+	switch tron.TxStatus(rl.Receipt.Status) {
+	case tron.TxConfirmed:
+		return justlend.ScheduleDone, rl.OrderId, nil
+	case tron.TxPending:
+		return justlend.SchedulePending, rl.OrderId, nil
+	default:
+		return justlend.SchedulePending, rl.OrderId, receiptError(rl.Receipt)
+	}
+}
+
+// lastRent reports whether the rent order is the only confirmed rent of its
+// rental that's not returned yet.
+func (ls *Service) lastRent(ctx context.Context, rent *justlend.OrderRL) (bool, error) {
+	rents, _, err := ls.findOrders(ctx, &justlend.OrdersMeta{
+		Owner:    rent.Owner,
+		Receiver: rent.Receiver,
+		Kind:     justlend.OrderRent,
+		Status:   justlend.OrderConfirmed,
+	}, access{}, false)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range rents {
+		if r.Type == rent.Type && r.Id != rent.Id {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// backoff returns the delay before the next attempt after the failures.
+func backoff(attempts int) time.Duration {
+	d := returnBackoff
+	for i := 1; i < attempts && d < maxReturnBackoff; i++ {
+		d *= 2
+	}
+	if d > maxReturnBackoff {
+		d = maxReturnBackoff
+	}
+	return d
+}
+
+const scheduleQuery = `
+	SELECT s.order_id, s.wallet, o.receiver, o.resource_type, s.status, s.return_at,
+		s.attempts, s.next_attempt_at, s.return_order_id, s.error, s.created_at, s.updated_at
+	FROM return_schedules s JOIN orders o ON o.id = s.order_id`
+
+func (ls *Service) findSchedules(ctx context.Context, clauses string, args ...any) ([]*justlend.ScheduleRL, error) {
+	var schedules []*justlend.ScheduleRL
+	err := ls.db.RunQuery(ctx, scheduleQuery+clauses, func(rows *sql.Rows) error {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return err
+		}
+		schedules = append(schedules, s)
+		return nil
+	}, args...)
+	return schedules, err
+}
+
+func (ls *Service) getSchedule(ctx context.Context, orderId string) (*justlend.ScheduleRL, error) {
+	s, err := scanSchedule(ls.db.QueryRow(ctx, scheduleQuery+` WHERE s.order_id = $1`, orderId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, derrors.NotFound
+	}
+	return s, err
+}
+
+func scanSchedule(s interface{ Scan(...any) error }) (*justlend.ScheduleRL, error) {
+	var (
+		sc       justlend.ScheduleRL
+		receiver internal.Address
+		rt       int32
+	)
+	if err := s.Scan(&sc.OrderId, &sc.Wallet, &receiver, &rt, &sc.Status, &sc.ReturnAt, &sc.Attempts,
+		&sc.NextAttemptAt, &sc.ReturnOrderId, &sc.Error, &sc.CreatedAt, &sc.UpdatedAt); err != nil {
+		return nil, err
+	}
+	sc.Receiver = receiver.String()
+	sc.Type = core.ResourceCode(rt)
+	return &sc, nil
+}
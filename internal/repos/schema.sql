@@ -0,0 +1,118 @@
+-- The schema is shared by SQLite & Postgres, keep to the common types.
+-- Statements are separated by semicolons, which must not appear elsewhere.
+
+CREATE TABLE IF NOT EXISTS orders (
+    id            TEXT PRIMARY KEY,
+    kind          TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    owner         BYTEA NOT NULL,
+    receiver      BYTEA,
+    resource_type INTEGER NOT NULL,
+    amount        BIGINT NOT NULL DEFAULT 0,
+    stake_per_trx BIGINT NOT NULL DEFAULT 0,
+    fee           BIGINT NOT NULL DEFAULT 0,
+    tx_id         TEXT NOT NULL DEFAULT '',
+    expires_at    TIMESTAMP,
+    error         TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMP NOT NULL,
+    updated_at    TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS orders_owner_idx ON orders (owner, created_at);
+CREATE INDEX IF NOT EXISTS orders_status_idx ON orders (status, created_at);
+CREATE INDEX IF NOT EXISTS orders_tx_id_idx ON orders (tx_id);
+
+-- The quotes are kept apart from the orders since they never move, & only
+-- for the retention window.
+CREATE TABLE IF NOT EXISTS quotes (
+    id            TEXT PRIMARY KEY,
+    owner         BYTEA NOT NULL,
+    resource_type INTEGER NOT NULL,
+    amount        BIGINT NOT NULL,
+    stake_per_trx BIGINT NOT NULL,
+    fee           BIGINT NOT NULL,
+    created_at    TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS quotes_created_at_idx ON quotes (created_at);
+
+CREATE TABLE IF NOT EXISTS order_events (
+    order_id   TEXT NOT NULL REFERENCES orders (id),
+    status     TEXT NOT NULL,
+    tx_id      TEXT NOT NULL DEFAULT '',
+    error      TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_events_order_id_idx ON order_events (order_id, created_at);
+
+CREATE TABLE IF NOT EXISTS return_schedules (
+    order_id        TEXT PRIMARY KEY REFERENCES orders (id),
+    wallet          TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    return_at       TIMESTAMP NOT NULL,
+    next_attempt_at TIMESTAMP NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    return_order_id TEXT NOT NULL DEFAULT '',
+    error           TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS return_schedules_due_idx ON return_schedules (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS watch_policies (
+    owner         BYTEA NOT NULL,
+    receiver      BYTEA NOT NULL,
+    resource_type INTEGER NOT NULL,
+    action        TEXT NOT NULL,
+    margin        BIGINT NOT NULL DEFAULT 0,
+    wallet        TEXT NOT NULL DEFAULT '',
+    top_up        BIGINT NOT NULL DEFAULT 0,
+    webhook       TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMP NOT NULL,
+    updated_at    TIMESTAMP NOT NULL,
+    PRIMARY KEY (owner, receiver, resource_type)
+);
+
+-- watch_states holds the last action of the watchdog on each rental, including
+-- the rentals of the default policy.
+CREATE TABLE IF NOT EXISTS watch_states (
+    owner         BYTEA NOT NULL,
+    receiver      BYTEA NOT NULL,
+    resource_type INTEGER NOT NULL,
+    acted_at      TIMESTAMP NOT NULL,
+    PRIMARY KEY (owner, receiver, resource_type)
+);
+
+CREATE TABLE IF NOT EXISTS maintain_policies (
+    id            TEXT PRIMARY KEY,
+    receiver      BYTEA NOT NULL,
+    wallet        TEXT NOT NULL,
+    target        BIGINT NOT NULL,
+    tolerance     BIGINT NOT NULL,
+    max_spend     BIGINT NOT NULL,
+    spent         BIGINT NOT NULL DEFAULT 0,
+    paused        BOOLEAN NOT NULL DEFAULT FALSE,
+    available     BIGINT NOT NULL DEFAULT 0,
+    checked_at    TIMESTAMP,
+    last_order_id TEXT NOT NULL DEFAULT '',
+    error         TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMP NOT NULL,
+    updated_at    TIMESTAMP NOT NULL
+);
+
+-- api_keys holds the issued API keys, only the SHA-256 of a key is stored. The
+-- wallets & the receivers are comma separated, either is unrestricted if it's
+-- empty.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    key_hash   TEXT NOT NULL,
+    scopes     TEXT NOT NULL,
+    wallets    TEXT NOT NULL DEFAULT '',
+    receivers  TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS api_keys_key_hash_idx ON api_keys (key_hash);
@@ -1,13 +1,178 @@
 package repos
 
-import "justlend/internal/tron"
+import (
+	"context"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"justlend/internal"
+	"justlend/internal/database"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/contract"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"math/big"
+	"time"
+)
 
 type Service struct {
-	tron *tron.Endpoint
+	tron   *tron.Endpoint
+	signer tron.Signer
+	rental *contract.EnergyRental
+	db     *database.DB
+	// params are the last known parameters of the rental contract.
+	params *paramCache
 }
 
-func NewService(endpoint *tron.Endpoint) *Service {
+// NewService creates a new service, the signer is optional and requests
+// referring to a wallet fail with derrors.NotFound if it's nil. The orders
+// are recorded in the ledger of the db, which must be migrated by Migrate.
+func NewService(endpoint *tron.Endpoint, signer tron.Signer, db *database.DB) *Service {
 	return &Service{
-		tron: endpoint,
+		tron:   endpoint,
+		signer: signer,
+		rental: contract.NewEnergyRental(justlend.JustLendContract, endpoint),
+		db:     db,
+		params: &paramCache{values: make(map[string]*big.Int)},
 	}
 }
+
+// payer resolves the signer and the address of the account that signs & pays
+// for the transaction, the wallet of the configured signer takes precedence
+// over the raw private key, which is signed in-process.
+func (ls *Service) payer(wallet, privateKey string) (tron.Signer, string, error) {
+	if internal.IsEmpty(wallet) {
+		s, err := tron.NewLocalSigner(privateKey)
+		if err != nil {
+			return nil, "", err
+		}
+		return s, s.Addresses()[0], nil
+	} else if ls.signer == nil {
+		return nil, "", derrors.NotFound
+	}
+	// Wallets of a resolver may be referred by identifiers, the
+	// others are referred by address only.
+	if r, ok := ls.signer.(tron.Resolver); ok {
+		address, err := r.Resolve(wallet)
+		return ls.signer, address, err
+	} else if !internal.IsValidAddress(wallet) {
+		return nil, "", derrors.InvalidParam
+	}
+	return ls.signer, wallet, nil
+}
+
+// execute signs & broadcasts the contract call of the order. The order is
+// recorded before the transaction is built and moves along with it, the
+// receipt of the transaction is returned if waited. The calls that would
+// revert are refused before they are signed.
+func (ls *Service) execute(ctx context.Context,
+	o *justlend.OrderRL,
+	signer tron.Signer,
+	data []byte,
+	callValue int64,
+	wait bool) (*justlend.TransactionRL, error) {
+
+	if err := ls.createOrder(ctx, o); err != nil {
+		return nil, err
+	}
+	tx, view, err := ls.tron.BuildContractTx(ctx, o.Owner, justlend.JustLendContract, data, callValue)
+	if err == nil && view.Reverted() {
+		err = fmt.Errorf("%w: %s: %s", derrors.InvalidParam, view.ContractResult, view.RevertReason)
+	}
+	if err != nil {
+		ls.logTransition(ctx, o.Id, justlend.OrderFailed, orderChange{Error: err.Error()})
+		return nil, err
+	}
+	txId, err := ls.tron.Sign(ctx, tx, signer, o.Owner)
+	if err != nil {
+		ls.logTransition(ctx, o.Id, justlend.OrderFailed, orderChange{Error: err.Error()})
+		return nil, err
+	}
+	if err = ls.tron.Broadcast(ctx, tx); err != nil {
+		ls.logTransition(ctx, o.Id, justlend.OrderFailed, orderChange{TxId: txId, Error: err.Error()})
+		return nil, err
+	}
+	o.TxId = txId
+	ls.logTransition(ctx, o.Id, justlend.OrderBroadcast, orderChange{
+		TxId:      txId,
+		ExpiresAt: time.UnixMilli(tx.GetRawData().GetExpiration()),
+	})
+	if !wait {
+		return nil, nil
+	}
+	receipt, err := ls.confirm(ctx, txId, tx)
+	if err != nil {
+		return nil, err
+	}
+	ls.settle(ctx, o, receipt)
+	return receipt, nil
+}
+
+// dryRun simulates the contract call of the owner & builds its unsigned
+// transaction.
+func (ls *Service) dryRun(ctx context.Context,
+	owner string,
+	contract string,
+	data []byte,
+	callValue int64) (*justlend.DryRunRL, error) {
+
+	tx, view, err := ls.tron.BuildContractTx(ctx, owner, contract, data, callValue)
+	if err != nil {
+		return nil, err
+	}
+	return toDryRunRL(owner, callValue, tx, view)
+}
+
+// export builds the unsigned transaction of the order to be signed offline,
+// the order is recorded along with the txID & the expiration of the
+// transaction, which is settled once the signed transaction is broadcast.
+// The calls that would revert are refused like the executed ones.
+func (ls *Service) export(ctx context.Context,
+	o *justlend.OrderRL,
+	data []byte,
+	callValue int64) (*justlend.DryRunRL, error) {
+
+	tx, view, err := ls.tron.BuildContractTx(ctx, o.Owner, justlend.JustLendContract, data, callValue)
+	if err != nil {
+		return nil, err
+	} else if view.Reverted() {
+		return nil, fmt.Errorf("%w: %s: %s", derrors.InvalidParam, view.ContractResult, view.RevertReason)
+	}
+	dr, err := toDryRunRL(o.Owner, callValue, tx, view)
+	if err != nil {
+		return nil, err
+	}
+	o.TxId, o.ExpiresAt = dr.TxId, &dr.Expiration
+	if err = ls.createOrder(ctx, o); err != nil {
+		return nil, err
+	}
+	return dr, nil
+}
+
+func toDryRunRL(owner string, callValue int64, tx *core.Transaction, view *tron.ViewResult) (*justlend.DryRunRL, error) {
+	txId, err := tron.TransactionId(tx)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tron.EncodeRawData(tx)
+	if err != nil {
+		return nil, err
+	}
+	rawJSON, err := protojson.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	return &justlend.DryRunRL{
+		Owner:          owner,
+		ContractResult: view.ContractResult.String(),
+		RevertReason:   view.RevertReason,
+		EnergyUsed:     view.EnergyUsed,
+		EnergyPenalty:  view.EnergyPenalty,
+		FeeLimit:       tx.GetRawData().GetFeeLimit(),
+		CallValue:      callValue,
+		TxId:           txId,
+		RawData:        raw,
+		RawDataJSON:    rawJSON,
+		Expiration:     time.UnixMilli(tx.GetRawData().GetExpiration()).UTC(),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"time"
+)
+
+func (ls *Service) Transaction(ctx context.Context,
+	req *justlend.TransactionMeta) (_ *justlend.TransactionRL, err error) {
+	defer derrors.WrapStack(&err, "ls.Transaction()")
+
+	r, err := ls.tron.TransactionStatus(ctx, req.TxId, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return toTransactionRL(r), nil
+}
+
+// confirm waits until the broadcast transaction is final and returns its
+// receipt. A pending receipt is returned if the context is done before that,
+// since the transaction is already broadcast and the txID must not be lost.
+func (ls *Service) confirm(ctx context.Context, txId string, tx *core.Transaction) (*justlend.TransactionRL, error) {
+	expiration := time.UnixMilli(tx.GetRawData().GetExpiration())
+	r, err := ls.tron.WaitTransaction(ctx, txId, expiration)
+	if errors.Is(err, derrors.Timeout) {
+		return &justlend.TransactionRL{TxId: txId, Status: string(tron.TxPending)}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return toTransactionRL(r), nil
+}
+
+func toTransactionRL(r *tron.TxReceipt) *justlend.TransactionRL {
+	return &justlend.TransactionRL{
+		TxId:           r.TxId,
+		Status:         string(r.Status),
+		BlockNumber:    r.BlockNumber,
+		BlockTimestamp: r.BlockTimestamp,
+		Fee:            r.Fee,
+		EnergyUsed:     r.EnergyUsed,
+		EnergyFee:      r.EnergyFee,
+		NetUsed:        r.NetUsed,
+		NetFee:         r.NetFee,
+		ContractResult: r.ContractResult,
+		ContractOutput: r.ContractOutput,
+		RevertReason:   r.RevertReason,
+	}
+}
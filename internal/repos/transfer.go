@@ -0,0 +1,175 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/contract"
+	"justlend/internal/log"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"math/big"
+)
+
+func (ls *Service) Transfer(ctx context.Context, req *justlend.TransferMeta) (_ *justlend.TransferRL, err error) {
+	defer derrors.WrapStack(&err, "ls.Transfer()")
+
+	signer, owner, err := ls.payer(req.Wallet, req.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := contract.PackTransfer(req.To, req.Value)
+	if err != nil {
+		return nil, err
+	}
+	energy, err := ls.tron.EstimateEnergy(ctx, owner, req.Token, data, 0)
+	if err != nil {
+		return nil, err
+	}
+	price, err := ls.tron.EnergyPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := ls.tron.GetAccountResource(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	rl := &justlend.TransferRL{
+		Energy:      energy,
+		Available:   max(res.GetEnergyLimit()-res.GetEnergyUsed(), 0),
+		EnergyPrice: price,
+	}
+
+	var rent *justlend.OrderRL
+	if shortfall := rl.Energy - rl.Available; shortfall > 0 {
+		// The rent itself consumes the energy of the sender first, which
+		// is rented as well then.
+		if rl.Available > 0 {
+			used, err := ls.rentEnergy(ctx, owner, shortfall)
+			if err != nil {
+				return nil, err
+			}
+			shortfall += min(used, rl.Available)
+		}
+		rented, err := ls.RentResource(ctx, &justlend.RentResourceMeta{
+			Receive:    owner,
+			Type:       core.ResourceCode_ENERGY,
+			Amount:     shortfall,
+			Wallet:     req.Wallet,
+			PrivateKey: req.PrivateKey,
+			Wait:       true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = receiptError(rented.Receipt); err != nil {
+			return nil, fmt.Errorf("rent %s: %w", rented.TxId, err)
+		}
+		if rent, err = ls.getOrder(ctx, rented.OrderId); err != nil {
+			return nil, err
+		}
+		rl.Rented, rl.RentOrderId, rl.RentTxId = shortfall, rent.Id, rent.TxId
+		rl.RentFee, rl.Fees = rent.Fee, rented.Receipt.Fee
+	}
+
+	tx, _, err := ls.tron.BuildContractTx(ctx, owner, req.Token, data, 0)
+	if err != nil {
+		return nil, err
+	}
+	txId, err := ls.tron.Sign(ctx, tx, signer, owner)
+	if err != nil {
+		return nil, err
+	}
+	if err = ls.tron.Broadcast(ctx, tx); err != nil {
+		return nil, err
+	}
+	rl.TxId = txId
+	if rl.Receipt, err = ls.confirm(ctx, txId, tx); err != nil {
+		return nil, err
+	}
+	rl.Fees += rl.Receipt.Fee
+
+	if req.Return && rent != nil {
+		if err = ls.returnRented(ctx, req, rent, rl); err != nil {
+			rl.ReturnError = err.Error()
+			log.WarnW("fails to return rented energy", "order", rent.Id, "error", err)
+		}
+	}
+	rl.TotalCost = rl.RentFee - rl.Refund + rl.Fees
+	rl.BurnCost = rl.Receipt.Fee + rl.Rented*rl.EnergyPrice
+	return rl, nil
+}
+
+// rentEnergy estimates the energy consumed by renting the amount of energy
+// to the owner.
+func (ls *Service) rentEnergy(ctx context.Context, owner string, amount int64) (int64, error) {
+	fee, err := ls.quote(ctx, &justlend.FeeRatioMeta{Owner: owner, Type: core.ResourceCode_ENERGY, Energy: amount})
+	if err != nil {
+		return 0, err
+	}
+	stakePerTrx := fee.StakePerTrx * tron.SUNPerTRX
+	data, err := ls.rental.PackRentResource(owner, big.NewInt(stakePerTrx), core.ResourceCode_ENERGY)
+	if err != nil {
+		return 0, err
+	}
+	return ls.tron.EstimateEnergy(ctx, owner, justlend.JustLendContract, data, tron.ToSUN(fee.PrePayFee.Decimal))
+}
+
+// returnRented returns the energy rented for the transfer, unless less is
+// left. The deposit refunded is estimated by the share of the returned stake
+// in the remaining deposit of the rental.
+func (ls *Service) returnRented(ctx context.Context,
+	req *justlend.TransferMeta,
+	rent *justlend.OrderRL,
+	rl *justlend.TransferRL) error {
+
+	if tron.TxStatus(rl.Receipt.Status) == tron.TxPending {
+		return fmt.Errorf("transfer %s: %w", rl.TxId, derrors.Timeout)
+	}
+	rental, err := ls.rentalOf(ctx, rent.Owner, rent.Receiver, rent.Type, decimal.Zero)
+	if err != nil || rental == nil {
+		return err
+	}
+	amount := min(rent.StakePerTrx, rental.StakePerTrx)
+	returned, err := ls.ReturnResource(ctx, &justlend.ReturnResourceMeta{
+		Receive:     rent.Receiver,
+		Type:        rent.Type,
+		StakePerTrx: amount,
+		Wallet:      req.Wallet,
+		PrivateKey:  req.PrivateKey,
+		Wait:        true,
+	})
+	if err != nil {
+		return err
+	}
+	rl.ReturnOrderId, rl.ReturnTxId = returned.OrderId, returned.TxId
+	rl.Fees += returned.Receipt.Fee
+	if err = receiptError(returned.Receipt); err != nil {
+		return err
+	}
+	rl.Refund = rental.RemainingDeposit.Shift(6).
+		Mul(decimal.NewFromInt(amount)).
+		Div(decimal.NewFromInt(rental.StakePerTrx)).
+		IntPart()
+	return nil
+}
+
+// receiptError returns the reason the transaction of the receipt is not
+// confirmed, derrors.Timeout if it's still pending.
+func receiptError(r *justlend.TransactionRL) error {
+	switch tron.TxStatus(r.Status) {
+	case tron.TxConfirmed:
+		return nil
+	case tron.TxPending:
+		return derrors.Timeout
+	}
+	reason := r.RevertReason
+	if internal.IsEmpty(reason) {
+		reason = r.Status
+	}
+	return errors.New(reason)
+}
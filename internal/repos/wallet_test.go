@@ -0,0 +1,27 @@
+package repos
+
+import (
+	"justlend/internal/tron/tronfake"
+	"testing"
+)
+
+// newFakeService creates a service calling a fake node in-process, it has
+// neither a signer nor a database.
+func newFakeService(t *testing.T) (*Service, *tronfake.Server) {
+	t.Helper()
+	return newFakeServiceOf(t, tronfake.DefaultParams())
+}
+
+// newFakeServiceOf is like newFakeService, with the chain parameters of the
+// fake node.
+func newFakeServiceOf(t *testing.T, p tronfake.Params) (*Service, *tronfake.Server) {
+	t.Helper()
+	node := tronfake.New(p)
+	t.Cleanup(node.Close)
+	endpoint, err := node.Endpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { endpoint.Close() })
+	return NewService(endpoint, nil, nil), node
+}
@@ -0,0 +1,349 @@
+package repos
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"justlend/internal"
+	"justlend/internal/config"
+	"justlend/internal/derrors"
+	"justlend/internal/justlend"
+	"justlend/internal/log"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+var (
+	// defaultWatchWebhook is the webhook of the rentals without a policy and
+	// of the policies without a webhook, the alerts are only logged if unset.
+	defaultWatchWebhook = config.GetEnv("WATCHDOG_WEBHOOK_URL", "")
+	// defaultWatchMargin is the margin in SUN of the rentals without a policy.
+	defaultWatchMargin = config.GetEnvInt64("WATCHDOG_MARGIN", 5000000)
+	// watchCooldown is the minimum interval between two actions on a rental,
+	// which leaves time for a top-up to be confirmed.
+	watchCooldown = config.GetEnvDuration("WATCHDOG_COOLDOWN", 600) * time.Second
+)
+
+// webhookClient posts the alerts.
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// rentalKey identifies a rental.
+type rentalKey struct {
+	owner, receiver string
+	rt              core.ResourceCode
+}
+
+func (ls *Service) SetWatch(ctx context.Context,
+	req *justlend.WatchPolicyMeta) (_ *justlend.WatchPolicyRL, err error) {
+	defer derrors.WrapStack(&err, "ls.SetWatch()")
+
+	if req.Action == justlend.WatchTopUp {
+		// Fail early rather than on the first top-up.
+		if _, _, err = ls.payer(req.Wallet, ""); err != nil {
+			return nil, err
+		}
+	}
+	at := now()
+	owner, receiver := internal.Address(internal.DecodeCheck(req.Renter)), internal.Address(internal.DecodeCheck(req.Receiver))
+	if _, err = ls.db.Exec(ctx, `
+		INSERT INTO watch_policies (owner, receiver, resource_type, action, margin,
+			wallet, top_up, webhook, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (owner, receiver, resource_type) DO UPDATE SET
+			action = excluded.action, margin = excluded.margin, wallet = excluded.wallet,
+			top_up = excluded.top_up, webhook = excluded.webhook, updated_at = excluded.updated_at`,
+		owner, receiver, int32(req.Type), req.Action, req.Margin,
+		req.Wallet, req.TopUp, req.Webhook, at, at); err != nil {
+		return nil, err
+	}
+	ps, err := ls.findWatches(ctx, ` WHERE p.owner = $1 AND p.receiver = $2 AND p.resource_type = $3`,
+		owner, receiver, int32(req.Type))
+	if err != nil {
+		return nil, err
+	} else if len(ps) == 0 {
+		return nil, derrors.InconsistentData
+	}
+	return ps[0], nil
+}
+
+func (ls *Service) Watches(ctx context.Context,
+	req *justlend.WatchesMeta) (_ []*justlend.WatchPolicyRL, _ int, err error) {
+	defer derrors.WrapStack(&err, "ls.Watches()")
+
+	var total int
+	if err = ls.db.QueryRow(ctx, `SELECT COUNT(*) FROM watch_policies`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	ps, err := ls.findWatches(ctx, ` ORDER BY p.created_at, p.owner, p.receiver, p.resource_type`+
+		limitOffset(req.Limit, req.Offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	return ps, total, nil
+}
+
+func (ls *Service) DeleteWatch(ctx context.Context, req *justlend.WatchMeta) (err error) {
+	defer derrors.WrapStack(&err, "ls.DeleteWatch()")
+
+	n, err := ls.db.Exec(ctx, `
+		DELETE FROM watch_policies WHERE owner = $1 AND receiver = $2 AND resource_type = $3`,
+		internal.Address(internal.DecodeCheck(req.Renter)),
+		internal.Address(internal.DecodeCheck(req.Receiver)),
+		int32(req.Type))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return derrors.NotFound
+	}
+	return nil
+}
+
+// Watch checks the tracked rentals, which are the rentals of the confirmed
+// rent orders that are not returned yet & the rentals with a policy.
+func (ls *Service) Watch(ctx context.Context) (err error) {
+	defer derrors.WrapStack(&err, "ls.Watch()")
+
+	keys, err := ls.trackedRentals(ctx)
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	policies, err := ls.findWatches(ctx, "")
+	if err != nil {
+		return err
+	}
+	byKey := make(map[rentalKey]*justlend.WatchPolicyRL, len(policies))
+	for _, p := range policies {
+		byKey[rentalKey{p.Renter, p.Receiver, p.Type}] = p
+	}
+	acted, err := ls.watchStates(ctx)
+	if err != nil {
+		return err
+	}
+	threshold, err := ls.liquidateThreshold(ctx, tron.ZeroAddress)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if ctx.Err() != nil {
+			return nil
+		}
+		p := byKey[k]
+		if p == nil {
+			p = &justlend.WatchPolicyRL{
+				Renter:   k.owner,
+				Receiver: k.receiver,
+				Type:     k.rt,
+				Action:   justlend.WatchAlert,
+				Margin:   defaultWatchMargin,
+			}
+		}
+		if at, ok := acted[k]; p.Action == justlend.WatchNone || (ok && time.Since(at) < watchCooldown) {
+			continue
+		}
+		if err := ls.watchRental(ctx, p, threshold); err != nil {
+			log.ErrorW("fails to watch rental", "renter", k.owner, "receiver", k.receiver, "type", k.rt, "error", err)
+		}
+	}
+	return nil
+}
+
+// watchRental takes the action of the policy if the deposit of the rental
+// runs low, a failed top-up falls back to an alert.
+func (ls *Service) watchRental(ctx context.Context, p *justlend.WatchPolicyRL, threshold decimal.Decimal) error {
+	rental, err := ls.rentalOf(ctx, p.Renter, p.Receiver, p.Type, threshold)
+	if err != nil {
+		return err
+	} else if rental == nil {
+		return nil
+	}
+	// Convert SUN into TRX.
+	floor := threshold.Add(decimal.NewFromInt(p.Margin).Shift(-6))
+	if !rental.RemainingDeposit.LessThan(floor) {
+		return nil
+	}
+
+	alert := &watchAlert{
+		Event:  "liquidation_risk",
+		Action: p.Action,
+		Rental: rental,
+		At:     now(),
+	}
+	if p.Action == justlend.WatchTopUp {
+		if alert.OrderId, err = ls.topUp(ctx, p); err != nil {
+			alert.Error = err.Error()
+		}
+	}
+	log.WarnW("rental deposit runs low", "renter", p.Renter, "receiver", p.Receiver, "type", p.Type,
+		"remaining", rental.RemainingDeposit, "threshold", threshold, "action", p.Action,
+		"order", alert.OrderId, "error", alert.Error)
+
+	webhook := p.Webhook
+	if internal.IsEmpty(webhook) {
+		webhook = defaultWatchWebhook
+	}
+	if !internal.IsEmpty(webhook) {
+		if err = postWebhook(ctx, webhook, alert); err != nil {
+			// Not acted, alert again on the next check.
+			return err
+		}
+	}
+	return ls.setActed(ctx, p, alert.At)
+}
+
+// topUpStake is the stake in SUN rented by a top-up, the least TRX a
+// delegation takes.
+const topUpStake = tron.SUNPerTRX
+
+// topUp adds the top-up of the policy to the security deposit. The rental
+// contract has no entry point paying the deposit alone, so the top-up rents
+// the least stake more: the call value pays the fee of the stake & the rest
+// of it is added to the deposit of the rental, as for every rent.
+func (ls *Service) topUp(ctx context.Context, p *justlend.WatchPolicyRL) (string, error) {
+	signer, owner, err := ls.payer(p.Wallet, "")
+	if err != nil {
+		return "", err
+	} else if owner != p.Renter {
+		// The wallet must be the renter of the rental.
+		return "", derrors.Forbidden
+	}
+	data, err := ls.rental.PackRentResource(p.Receiver, big.NewInt(topUpStake), p.Type)
+	if err != nil {
+		return "", err
+	}
+	o := &justlend.OrderRL{
+		Kind:        justlend.OrderTopUp,
+		Owner:       owner,
+		Receiver:    p.Receiver,
+		Type:        p.Type,
+		StakePerTrx: topUpStake,
+		Fee:         p.TopUp,
+	}
+	if _, err = ls.execute(ctx, o, signer, data, p.TopUp, false); err != nil {
+		return o.Id, err
+	}
+	return o.Id, nil
+}
+
+// watchAlert is the payload posted to the webhooks.
+type watchAlert struct {
+	Event  string               `json:"event"`
+	Action justlend.WatchAction `json:"action"`
+	Rental *justlend.RentalRL   `json:"rental"`
+	// OrderId is the top-up order if any.
+	OrderId string    `json:"orderId,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+func postWebhook(ctx context.Context, url string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// trackedRentals returns the distinct rentals watched by the watchdog.
+func (ls *Service) trackedRentals(ctx context.Context) ([]rentalKey, error) {
+	var keys []rentalKey
+	err := ls.db.RunQuery(ctx, `
+		SELECT owner, receiver, resource_type FROM orders WHERE kind = $1 AND status = $2
+		UNION
+		SELECT owner, receiver, resource_type FROM watch_policies`, func(rows *sql.Rows) error {
+		k, err := scanRentalKey(rows)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+		return nil
+	}, justlend.OrderRent, justlend.OrderConfirmed)
+	return keys, err
+}
+
+func (ls *Service) watchStates(ctx context.Context) (map[rentalKey]time.Time, error) {
+	acted := make(map[rentalKey]time.Time)
+	err := ls.db.RunQuery(ctx, `SELECT owner, receiver, resource_type, acted_at FROM watch_states`,
+		func(rows *sql.Rows) error {
+			var (
+				owner, receiver internal.Address
+				rt              int32
+				at              time.Time
+			)
+			if err := rows.Scan(&owner, &receiver, &rt, &at); err != nil {
+				return err
+			}
+			acted[rentalKey{owner.String(), receiver.String(), core.ResourceCode(rt)}] = at
+			return nil
+		})
+	return acted, err
+}
+
+func (ls *Service) setActed(ctx context.Context, p *justlend.WatchPolicyRL, at time.Time) error {
+	_, err := ls.db.Exec(ctx, `
+		INSERT INTO watch_states (owner, receiver, resource_type, acted_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (owner, receiver, resource_type) DO UPDATE SET acted_at = excluded.acted_at`,
+		internal.Address(internal.DecodeCheck(p.Renter)),
+		internal.Address(internal.DecodeCheck(p.Receiver)),
+		int32(p.Type), at)
+	return err
+}
+
+func scanRentalKey(rows *sql.Rows) (rentalKey, error) {
+	var (
+		owner, receiver internal.Address
+		rt              int32
+	)
+	if err := rows.Scan(&owner, &receiver, &rt); err != nil {
+		return rentalKey{}, err
+	}
+	return rentalKey{owner.String(), receiver.String(), core.ResourceCode(rt)}, nil
+}
+
+const watchQuery = `
+	SELECT p.owner, p.receiver, p.resource_type, p.action, p.margin, p.wallet, p.top_up,
+		p.webhook, s.acted_at, p.created_at, p.updated_at
+	FROM watch_policies p LEFT JOIN watch_states s ON s.owner = p.owner
+		AND s.receiver = p.receiver AND s.resource_type = p.resource_type`
+
+func (ls *Service) findWatches(ctx context.Context, clauses string, args ...any) ([]*justlend.WatchPolicyRL, error) {
+	var ps []*justlend.WatchPolicyRL
+	err := ls.db.RunQuery(ctx, watchQuery+clauses, func(rows *sql.Rows) error {
+		var (
+			p               justlend.WatchPolicyRL
+			owner, receiver internal.Address
+			rt              int32
+			actedAt         sql.NullTime
+		)
+		if err := rows.Scan(&owner, &receiver, &rt, &p.Action, &p.Margin, &p.Wallet, &p.TopUp,
+			&p.Webhook, &actedAt, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return err
+		}
+		p.Renter, p.Receiver, p.Type = owner.String(), receiver.String(), core.ResourceCode(rt)
+		if actedAt.Valid {
+			p.ActedAt = &actedAt.Time
+		}
+		ps = append(ps, &p)
+		return nil
+	}, args...)
+	return ps, err
+}
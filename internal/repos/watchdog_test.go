@@ -0,0 +1,135 @@
+package repos
+
+import (
+	"context"
+	"justlend/internal/justlend"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"justlend/internal/tron/tronfake"
+	"math/big"
+	"testing"
+)
+
+const receiver = "TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7"
+
+// newRental creates a ledger service of a signer holding the renter's key, &
+// rents 1000 TRX of stake to the receiver with a confirmed rent order.
+func newRental(t *testing.T) (*Service, *justlend.OrderRL) {
+	t.Helper()
+	ls, node := newLedgerService(t)
+	signer, err := tron.NewLocalSigner("8e812436a0e3323166e1f0e8ba79e19e217b2c4a53c970d4cca0cfb1078979df")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls.signer = signer
+	renter := signer.Addresses()[0]
+	node.SetBalance(renter, 1000*tron.SUNPerTRX)
+
+	stake := int64(1000 * tron.SUNPerTRX)
+	data, err := ls.rental.PackRentResource(receiver, big.NewInt(stake), core.ResourceCode_ENERGY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rent := &justlend.OrderRL{
+		Kind:        justlend.OrderRent,
+		Owner:       renter,
+		Receiver:    receiver,
+		Type:        core.ResourceCode_ENERGY,
+		StakePerTrx: stake,
+	}
+	if _, err = ls.execute(context.Background(), rent, signer, data, 100*tron.SUNPerTRX, true); err != nil {
+		t.Fatal(err)
+	}
+	return ls, rent
+}
+
+// TestTopUp tops up a rental on the fake node, whose rental contract adds the
+// call value less the fee of the rented stake to the deposit.
+func TestTopUp(t *testing.T) {
+	ls, rent := newRental(t)
+	ctx := context.Background()
+	renter := rent.Owner
+	before, err := ls.rental.GetRentInfo(ctx, renter, receiver, core.ResourceCode_ENERGY)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &justlend.WatchPolicyRL{
+		Renter:   renter,
+		Receiver: receiver,
+		Type:     core.ResourceCode_ENERGY,
+		Action:   justlend.WatchTopUp,
+		Wallet:   renter,
+		TopUp:    20 * tron.SUNPerTRX,
+	}
+	id, err := ls.topUp(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := ls.rental.GetRentInfo(ctx, renter, receiver, core.ResourceCode_ENERGY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The fee of 1 TRX of stake is the minimum fee.
+	fee := tronfake.DefaultParams().MinFee
+	if got, want := new(big.Int).Sub(after.SecurityDeposit, before.SecurityDeposit).Int64(), p.TopUp-fee; got != want {
+		t.Errorf("deposit topped up by %d SUN, want %d", got, want)
+	}
+	if got := new(big.Int).Sub(after.Amount, before.Amount).Int64(); got != topUpStake {
+		t.Errorf("stake topped up by %d SUN, want %d", got, topUpStake)
+	}
+	o, err := ls.getOrder(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	} else if o.Kind != justlend.OrderTopUp || o.Fee != p.TopUp || o.TxId == "" {
+		t.Errorf("top-up order = %+v", o)
+	}
+
+	// A top-up short of the fee & the liquidation threshold is refused
+	// before it is signed.
+	p.TopUp = fee
+	if _, err = ls.topUp(ctx, p); err == nil {
+		t.Error("topUp() of the bare fee succeeded")
+	}
+}
+
+// TestTopUpReturn returns the rental of a single rent order after a top-up,
+// the scheduled return takes back the stake of the top-up too.
+func TestTopUpReturn(t *testing.T) {
+	ls, rent := newRental(t)
+	ctx := context.Background()
+	renter := rent.Owner
+	if _, err := ls.topUp(ctx, &justlend.WatchPolicyRL{
+		Renter:   renter,
+		Receiver: receiver,
+		Type:     core.ResourceCode_ENERGY,
+		Action:   justlend.WatchTopUp,
+		Wallet:   renter,
+		TopUp:    20 * tron.SUNPerTRX,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ls.scheduleReturn(ctx, rent, renter, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := ls.ReturnDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := ls.getSchedule(ctx, rent.Id); err != nil {
+		t.Fatal(err)
+	} else if s.Status != justlend.ScheduleDone {
+		t.Fatalf("schedule = %s (%s), want %s", s.Status, s.Error, justlend.ScheduleDone)
+	}
+	info, err := ls.rental.GetRentInfo(ctx, renter, receiver, core.ResourceCode_ENERGY)
+	if err != nil {
+		t.Fatal(err)
+	} else if info.Amount.Sign() != 0 {
+		t.Errorf("rental of %s SUN left after the return", info.Amount)
+	}
+	if o, err := ls.getOrder(ctx, rent.Id); err != nil {
+		t.Fatal(err)
+	} else if o.Status != justlend.OrderReturned {
+		t.Errorf("rent order = %s, want %s", o.Status, justlend.OrderReturned)
+	}
+}
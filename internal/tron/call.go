@@ -0,0 +1,130 @@
+package tron
+
+import (
+	"context"
+	"fmt"
+	"justlend/internal"
+	"justlend/internal/config"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/core"
+	"time"
+)
+
+// txExpiration is the lifetime of the built transactions, which must be
+// signed & broadcast before they expire.
+var txExpiration = config.GetEnvDuration("TRON_TX_EXPIRATION", 60) * time.Second
+
+// ViewResult is the result of a contract call simulated by a node, nothing
+// is signed nor changed on chain.
+type ViewResult struct {
+	// Results are the ABI encoded outputs of the call.
+	Results [][]byte
+	// ContractResult is SUCCESS unless the call would fail, i.e. REVERT,
+	// RevertReason is the reason of the failure then.
+	ContractResult core.Transaction_ResultContractResult
+	RevertReason   string
+	EnergyUsed     int64
+	EnergyPenalty  int64
+}
+
+// Reverted reports whether the simulated call would fail.
+func (v *ViewResult) Reverted() bool {
+	return !internal.Contains(v.ContractResult, core.Transaction_Result_DEFAULT, core.Transaction_Result_SUCCESS)
+}
+
+// CallView simulates the call of the contract on behalf of the given owner
+// address without signing anything, it serves the read-only views as well as
+// the dry runs of the transactions. The ZeroAddress is used as the caller if
+// owner is empty.
+func (e *Endpoint) CallView(ctx context.Context,
+	owner string,
+	contract string,
+	data []byte,
+	callValue int64) (*ViewResult, error) {
+
+	if internal.IsEmpty(owner) {
+		owner = ZeroAddress
+	}
+	reply, err := e.wallet.TriggerConstantContract(ctx, &core.TriggerSmartContract{
+		OwnerAddress:    internal.DecodeCheck(owner),
+		ContractAddress: internal.DecodeCheck(contract),
+		Data:            data,
+		CallValue:       callValue,
+	})
+	if err != nil {
+		return nil, err
+	} else if !reply.GetResult().GetResult() {
+		return nil, fmt.Errorf("constant call: %s", reply.GetResult().GetMessage())
+	}
+	v := &ViewResult{
+		Results:        reply.GetConstantResult(),
+		ContractResult: core.Transaction_Result_SUCCESS,
+		EnergyUsed:     reply.GetEnergyUsed(),
+		EnergyPenalty:  reply.GetEnergyPenalty(),
+	}
+	for _, ret := range reply.GetTransaction().GetRet() {
+		if ret.GetContractRet() != core.Transaction_Result_DEFAULT {
+			v.ContractResult = ret.GetContractRet()
+		}
+	}
+	if v.Reverted() {
+		v.RevertReason = decodeRevertReason(v.Results, reply.GetResult().GetMessage())
+	}
+	return v, nil
+}
+
+// BuildContractTx builds the unsigned transaction calling the contract on
+// behalf of the owner address. The call is simulated first, its energy sets
+// the fee limit of the transaction, which expires in TRON_TX_EXPIRATION
+// seconds. The simulation is returned along with the transaction, a call
+// that would revert is built all the same.
+func (e *Endpoint) BuildContractTx(ctx context.Context,
+	owner string,
+	contract string,
+	data []byte,
+	callValue int64) (*core.Transaction, *ViewResult, error) {
+
+	call := &core.TriggerSmartContract{
+		OwnerAddress:    internal.DecodeCheck(owner),
+		ContractAddress: internal.DecodeCheck(contract),
+		Data:            data,
+		CallValue:       callValue,
+	}
+	view, err := e.CallView(ctx, owner, contract, data, callValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	feeLimit, err := e.feeLimit(ctx, call, view.EnergyUsed)
+	if err != nil {
+		return nil, view, err
+	}
+	reply, err := e.wallet.TriggerContract(ctx, call)
+	if err != nil {
+		return nil, view, err
+	} else if !reply.GetResult().GetResult() {
+		return nil, view, fmt.Errorf("build transaction: %s", reply.GetResult().GetMessage())
+	}
+	tx := reply.GetTransaction()
+	if tx == nil || len(tx.GetRawData().GetContract()) == 0 {
+		return nil, view, fmt.Errorf("build transaction: %w: invalid transaction", derrors.InconsistentData)
+	}
+	tx.RawData.FeeLimit = feeLimit
+	tx.RawData.Expiration = time.Now().Add(txExpiration).UnixMilli()
+	return tx, view, nil
+}
+
+// Sign signs the transaction with the key of the owner address and returns
+// its hex encoded txID, the raw data is signed as built.
+func (e *Endpoint) Sign(ctx context.Context, tx *core.Transaction, signer Signer, owner string) (string, error) {
+	return SignTransaction(ctx, tx, signer, owner)
+}
+
+// Broadcast broadcasts the signed transaction.
+func (e *Endpoint) Broadcast(ctx context.Context, tx *core.Transaction) error {
+	if reply, err := e.wallet.BroadcastTransaction(ctx, tx); err != nil {
+		return err
+	} else if !reply.GetResult() {
+		return fmt.Errorf("broadcast: %s: %s", reply.GetCode(), reply.GetMessage())
+	}
+	return nil
+}
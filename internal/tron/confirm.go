@@ -0,0 +1,151 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"justlend/internal/abi"
+	"justlend/internal/config"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/api"
+	"justlend/internal/protos/core"
+	"strings"
+	"time"
+)
+
+var (
+	// defaultSolidityEndpoint is the optional solidity node used to confirm
+	// the transactions, the transactions are confirmed once they are in a
+	// block of the full node if it's not set.
+	defaultSolidityEndpoint = config.GetEnv("TRON_SOLIDITY_ENDPOINT", "")
+	// confirmInterval is the interval between two polls of a transaction.
+	confirmInterval = config.GetEnvDuration("TRON_CONFIRM_INTERVAL", 3) * time.Second
+)
+
+// TxStatus is the status of a broadcast transaction.
+type TxStatus string
+
+const (
+	// TxPending means the transaction is not confirmed yet.
+	TxPending TxStatus = "pending"
+	// TxConfirmed means the transaction is confirmed & the contract call succeeded.
+	TxConfirmed TxStatus = "confirmed"
+	// TxReverted means the transaction is confirmed but the contract call failed.
+	TxReverted TxStatus = "reverted"
+	// TxExpired means the transaction was not packed into a block before
+	// its expiration and will never be.
+	TxExpired TxStatus = "expired"
+)
+
+// Final reports whether the status will not change any more.
+func (s TxStatus) Final() bool { return s != TxPending }
+
+// TxReceipt is the receipt of a transaction.
+type TxReceipt struct {
+	TxId           string   `json:"txId"`
+	Status         TxStatus `json:"status"`
+	BlockNumber    int64    `json:"blockNumber,omitempty"`
+	BlockTimestamp int64    `json:"blockTimestamp,omitempty"`
+	// Fee is the total fee burnt by the transaction in SUN.
+	Fee        int64 `json:"fee"`
+	EnergyUsed int64 `json:"energyUsed"`
+	EnergyFee  int64 `json:"energyFee"`
+	NetUsed    int64 `json:"netUsed"`
+	NetFee     int64 `json:"netFee"`
+	// ContractResult is the VM result of the call, i.e. SUCCESS or REVERT.
+	ContractResult string `json:"contractResult,omitempty"`
+	// ContractOutput is the hex return data of the call.
+	ContractOutput string `json:"contractOutput,omitempty"`
+	RevertReason   string `json:"revertReason,omitempty"`
+}
+
+// TransactionStatus checks the current status of the transaction once. The
+// expiration is the expiration time of the transaction, which is used to
+// detect the expired transactions, pass a zero time if it's unknown.
+func (e *Endpoint) TransactionStatus(ctx context.Context, txId string, expiration time.Time) (*TxReceipt, error) {
+	id, err := hex.DecodeString(txId)
+	if err != nil || len(id) != 32 {
+		return nil, derrors.InvalidParam
+	}
+	in := &api.BytesMessage{Value: id}
+
+	var info *core.TransactionInfo
+	if e.solidity != nil {
+		info, err = e.solidity.GetTransactionInfoById(ctx, in)
+	} else {
+		info, err = e.wallet.GetTransactionInfoById(ctx, in)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(info.GetId()) == 0 {
+		// Not found, the transaction is either pending or expired.
+		status := TxPending
+		if !expiration.IsZero() && time.Now().After(expiration) {
+			status = TxExpired
+		}
+		return &TxReceipt{TxId: txId, Status: status}, nil
+	}
+	return newTxReceipt(txId, info), nil
+}
+
+// WaitTransaction polls the transaction until its status is final or the
+// context is done.
+func (e *Endpoint) WaitTransaction(ctx context.Context, txId string, expiration time.Time) (*TxReceipt, error) {
+	t := time.NewTicker(confirmInterval)
+	defer t.Stop()
+	for {
+		r, err := e.TransactionStatus(ctx, txId, expiration)
+		if err == nil && r.Status.Final() {
+			return r, nil
+		} else if err != nil && !retryable(ctx, err) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, derrors.Timeout
+		case <-t.C:
+		}
+	}
+}
+
+func newTxReceipt(txId string, info *core.TransactionInfo) *TxReceipt {
+	r := &TxReceipt{
+		TxId:           txId,
+		Status:         TxConfirmed,
+		BlockNumber:    info.GetBlockNumber(),
+		BlockTimestamp: info.GetBlockTimeStamp(),
+		Fee:            info.GetFee(),
+		EnergyUsed:     info.GetReceipt().GetEnergyUsageTotal(),
+		EnergyFee:      info.GetReceipt().GetEnergyFee(),
+		NetUsed:        info.GetReceipt().GetNetUsage(),
+		NetFee:         info.GetReceipt().GetNetFee(),
+		ContractResult: info.GetReceipt().GetResult().String(),
+	}
+	if len(info.GetContractResult()) > 0 {
+		r.ContractOutput = hex.EncodeToString(info.GetContractResult()[0])
+	}
+	res := info.GetReceipt().GetResult()
+	if info.GetResult() == core.TransactionInfo_FAILED ||
+		(res != core.Transaction_Result_DEFAULT && res != core.Transaction_Result_SUCCESS) {
+		r.Status = TxReverted
+		r.RevertReason = revertReason(info)
+	}
+	return r
+}
+
+// revertReason decodes the revert reason of the failed transaction, the
+// result message of the node is used if the reason is not ABI encoded.
+func revertReason(info *core.TransactionInfo) string {
+	return decodeRevertReason(info.GetContractResult(), info.GetResMessage())
+}
+
+// decodeRevertReason decodes the revert data of the outputs, the message is
+// returned if the reason is not ABI encoded.
+func decodeRevertReason(out [][]byte, message []byte) string {
+	if len(out) > 0 {
+		if reason, err := abi.UnpackRevert(out[0]); err == nil {
+			return reason
+		}
+	}
+	return strings.TrimSpace(string(message))
+}
@@ -0,0 +1,140 @@
+package tron
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"justlend/internal/config"
+	"os"
+)
+
+var (
+	// defaultNodesFile is a JSON file of the []NodeConfig which configures
+	// each node individually, it takes precedence over TRON_GRPC_ENDPOINTS.
+	defaultNodesFile = config.GetEnv("TRON_NODES_FILE", "")
+	// Default credentials of the nodes listed in TRON_GRPC_ENDPOINTS.
+	defaultNodeTLS      = config.GetEnvBool("TRON_GRPC_TLS", false)
+	defaultNodeCAFile   = config.GetEnv("TRON_GRPC_CA_FILE", "")
+	defaultNodeCertFile = config.GetEnv("TRON_GRPC_CERT_FILE", "")
+	defaultNodeKeyFile  = config.GetEnv("TRON_GRPC_KEY_FILE", "")
+	defaultNodeAPIKey   = config.GetEnvSecret("TRON_PRO_API_KEY", "")
+)
+
+// apiKeyHeader is the metadata key of the API key of the TronGrid-style
+// hosted nodes.
+const apiKeyHeader = "TRON-PRO-API-KEY"
+
+// NodeConfig configures the connection to a Tron full node.
+type NodeConfig struct {
+	// Addr is the gRPC address of the node.
+	Addr string `json:"addr"`
+	// TLS enables the transport security, the system roots are used to
+	// verify the node unless CAFile is set.
+	TLS        bool   `json:"tls"`
+	CAFile     string `json:"caFile,omitempty"`
+	ServerName string `json:"serverName,omitempty"`
+	// CertFile & KeyFile are the client certificate presented to the node.
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+	// APIKey is sent as the TRON-PRO-API-KEY metadata of every call.
+	APIKey string `json:"apiKey,omitempty"`
+	// Headers are the extra metadata sent with every call.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// loadNodeConfigs loads the node configs from TRON_NODES_FILE if it's set,
+// otherwise every node of TRON_GRPC_ENDPOINTS uses the default credentials.
+func loadNodeConfigs() ([]NodeConfig, error) {
+	if defaultNodesFile != "" {
+		blob, err := os.ReadFile(defaultNodesFile)
+		if err != nil {
+			return nil, err
+		}
+		var cs []NodeConfig
+		if err = json.Unmarshal(blob, &cs); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", defaultNodesFile, err)
+		}
+		return cs, nil
+	}
+	cs := make([]NodeConfig, 0, len(defaultTrongRPCEndpoints))
+	for _, addr := range defaultTrongRPCEndpoints {
+		cs = append(cs, defaultNodeConfig(addr))
+	}
+	return cs, nil
+}
+
+// defaultNodeConfig returns the config of the node using the default credentials.
+func defaultNodeConfig(addr string) NodeConfig {
+	return NodeConfig{
+		Addr:     addr,
+		TLS:      defaultNodeTLS,
+		CAFile:   defaultNodeCAFile,
+		CertFile: defaultNodeCertFile,
+		KeyFile:  defaultNodeKeyFile,
+		APIKey:   defaultNodeAPIKey,
+	}
+}
+
+// DialOptions returns the dial options of the transport & per-RPC credentials.
+func (c NodeConfig) DialOptions() ([]grpc.DialOption, error) {
+	creds := insecure.NewCredentials()
+	if c.TLS {
+		tc := &tls.Config{ServerName: c.ServerName, MinVersion: tls.VersionTLS12}
+		if c.CAFile != "" {
+			pem, err := os.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			tc.RootCAs = x509.NewCertPool()
+			if !tc.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in %s", c.CAFile)
+			}
+		}
+		if c.CertFile != "" || c.KeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+			if err != nil {
+				return nil, err
+			}
+			tc.Certificates = []tls.Certificate{cert}
+		}
+		creds = credentials.NewTLS(tc)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+
+	md := make(map[string]string, len(c.Headers)+1)
+	for k, v := range c.Headers {
+		md[k] = v
+	}
+	if c.APIKey != "" {
+		md[apiKeyHeader] = c.APIKey
+	}
+	if len(md) > 0 {
+		opts = append(opts, grpc.WithPerRPCCredentials(metadataCredentials{md: md, secure: c.TLS}))
+	}
+	return opts, nil
+}
+
+// metadataCredentials attaches the static metadata to every call.
+type metadataCredentials struct {
+	md     map[string]string
+	secure bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (m metadataCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return m.md, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials, the
+// metadata is allowed on plaintext connections only if TLS is disabled
+// explicitly for the node.
+func (m metadataCredentials) RequireTransportSecurity() bool { return m.secure }
+
+var (
+	_ credentials.PerRPCCredentials = metadataCredentials{}
+)
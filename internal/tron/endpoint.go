@@ -2,54 +2,115 @@ package tron
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"github.com/shopspring/decimal"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"justlend/internal"
 	"justlend/internal/config"
 	"justlend/internal/derrors"
 	"justlend/internal/protos/api"
 	"justlend/internal/protos/core"
-	"log"
-	"math"
+	"math/big"
+	"time"
 )
 
 var (
 	defaultTrongRPCEndpoint = config.GetEnv("TRON_GRPC_ENDPOINT", "34.220.77.106:50051")
+	// defaultTrongRPCEndpoints lists the full nodes of the pool in the order
+	// of priority, the single TRON_GRPC_ENDPOINT is used if it's not set.
+	defaultTrongRPCEndpoints = config.GetEnvList("TRON_GRPC_ENDPOINTS", defaultTrongRPCEndpoint)
+	// Node health check settings of the pool.
+	healthInterval = config.GetEnvDuration("TRON_HEALTH_INTERVAL", 10) * time.Second
+	maxBlockLag    = config.GetEnvInt64("TRON_MAX_BLOCK_LAG", 5)
+	// feeLimitMultiplier is the safety margin of the estimated energy of the
+	// calls, the fee limit of a call covers the margined energy burnt at the
+	// current energy price.
+	feeLimitMultiplier = config.GetEnvFloat64("FEE_LIMIT_MULTIPLIER", 1.2)
+	// maxFeeLimit is the ceiling in SUN of the fee limits, the calls whose
+	// fee limit is estimated above it are refused.
+	maxFeeLimit = config.GetEnvInt64("FEE_LIMIT_MAX", 100*SUNPerTRX)
 )
 
+// ZeroAddress is the base58 form of the Tron zero address, it's used as a
+// neutral caller for the read-only contract calls that don't care about the
+// owner of the call.
+const ZeroAddress = "T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb"
+
 type Endpoint struct {
-	node   string           // endpoint of the Tron node
-	grpc   *grpc.ClientConn // client connection to the Wallet service
+	pool   *Pool            // pool of the Tron full nodes
 	wallet api.WalletClient // client API for wallet service
+
+	// Optional solidity node used to confirm the transactions.
+	solidityConn *grpc.ClientConn
+	solidity     api.WalletSolidityClient
 }
 
-// NewEndpoint creates a new endpoint to the Tron node.
+// NewEndpoint creates a new endpoint to the pool of the configured Tron nodes,
+// whose calls are recorded or replayed as TRON_REPLAY configures.
 func NewEndpoint() (*Endpoint, error) {
-	// Create a new gRPC client connection to the Tron node.
-	conn, err := grpc.NewClient(
-		defaultTrongRPCEndpoint,
-		// Use insecure credentials for now.
-		grpc.WithTransportCredentials(
-			insecure.NewCredentials(),
-		),
-	)
+	rec, err := NewRecorder(defaultReplayMode, defaultReplayFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewRecordedEndpoint(rec)
+}
+
+// NewRecordedEndpoint creates a new endpoint to the pool of the configured
+// Tron nodes, whose calls go through the recorder.
+func NewRecordedEndpoint(rec *Recorder) (*Endpoint, error) {
+	nodes, err := loadNodeConfigs()
+	if err != nil {
+		return nil, err
+	}
+	// Create a new gRPC client connection to each Tron node with
+	// the credentials of the node.
+	dial := func(c NodeConfig) (*grpc.ClientConn, error) {
+		opts, err := c.DialOptions()
+		if err != nil {
+			return nil, err
+		}
+		if opt := rec.DialOption(); opt != nil {
+			opts = append(opts, opt)
+		}
+		return grpc.NewClient(c.Addr, opts...)
+	}
+	pool, err := NewPool(dial, nodes...)
 	if err != nil {
-		log.Panic(err)
+		return nil, err
 	}
+	pool.Interval, pool.MaxLag = healthInterval, maxBlockLag
+	e := NewEndpointFromPool(pool)
+	if defaultSolidityEndpoint != "" {
+		if e.solidityConn, err = dial(defaultNodeConfig(defaultSolidityEndpoint)); err != nil {
+			pool.Close()
+			return nil, err
+		}
+		e.solidity = api.NewWalletSolidityClient(e.solidityConn)
+	}
+	return e, nil
+}
+
+// NewEndpointFromPool creates a new endpoint to the pool without a solidity
+// node, i.e. to the nodes served in-process.
+func NewEndpointFromPool(pool *Pool) *Endpoint {
 	return &Endpoint{
-		node:   defaultTrongRPCEndpoint,
-		grpc:   conn,
-		wallet: api.NewWalletClient(conn),
-	}, nil
+		pool:   pool,
+		wallet: api.NewWalletClient(pool),
+	}
 }
 
+// Run runs the health checks of the nodes until the context is canceled.
+func (e *Endpoint) Run(ctx context.Context) error { return e.pool.Run(ctx) }
+
+// Nodes returns the status of the Tron nodes.
+func (e *Endpoint) Nodes() []NodeStatus { return e.pool.Nodes() }
+
 const SUNPerTRX = 1000000
 
-func ToSUN(trx float64) int64 {
-	return int64(trx * SUNPerTRX)
+// ToSUN converts the TRX amount into SUN, the fractions of a SUN are rounded
+// up so that a payment never falls short.
+func ToSUN(trx decimal.Decimal) int64 {
+	return trx.Shift(6).Ceil().IntPart()
 }
 
 // GetAccountResource Retrieve the account resource using the wallet client
@@ -57,84 +118,182 @@ func (e *Endpoint) GetAccountResource(ctx context.Context, address string) (*api
 	return e.wallet.GetAccountResource(ctx, &core.Account{Address: internal.DecodeCheck(address)})
 }
 
-func (e *Endpoint) TriggerConstantContract(ctx context.Context,
+// EstimateEnergy estimates the energy consumed by the contract call of the
+// owner. The nodes without the estimation API enabled are asked for a dry run
+// of the call, whose energy used is returned then.
+func (e *Endpoint) EstimateEnergy(ctx context.Context,
+	owner string,
 	contract string,
 	data []byte,
-	privateKey string,
-	callValue int64) (*api.TransactionExtention, string, error) {
+	callValue int64) (int64, error) {
+
+	call := &core.TriggerSmartContract{
+		OwnerAddress:    internal.DecodeCheck(owner),
+		ContractAddress: internal.DecodeCheck(contract),
+		Data:            data,
+		CallValue:       callValue,
+	}
+	if reply, err := e.wallet.EstimateEnergy(ctx, call); err == nil && reply.GetResult().GetResult() {
+		return reply.GetEnergyRequired(), nil
+	}
+	view, err := e.CallView(ctx, owner, contract, data, callValue)
+	if err != nil {
+		return 0, err
+	} else if view.Reverted() {
+		// The call that would revert, i.e. of an insufficient balance, is
+		// refused rather than estimated.
+		return 0, fmt.Errorf("estimate energy: %w: %s", derrors.InvalidParam, view.RevertReason)
+	}
+	return view.EnergyUsed, nil
+}
+
+// feeLimit returns the fee limit in SUN of the call whose dry run used the
+// energy, the estimation API is asked if the dry run reported no energy.
+func (e *Endpoint) feeLimit(ctx context.Context, call *core.TriggerSmartContract, energy int64) (int64, error) {
+	if energy <= 0 {
+		reply, err := e.wallet.EstimateEnergy(ctx, call)
+		if err != nil {
+			return 0, err
+		} else if energy = reply.GetEnergyRequired(); energy <= 0 {
+			return 0, fmt.Errorf("fee limit: %w: no energy estimated", derrors.InconsistentData)
+		}
+	}
+	price, err := e.EnergyPrice(ctx)
+	if err != nil {
+		return 0, err
+	}
+	limit := FeeLimit(energy, price, feeLimitMultiplier)
+	if err := checkFeeLimit(limit, energy, maxFeeLimit); err != nil {
+		return 0, err
+	}
+	return limit, nil
+}
+
+// checkFeeLimit refuses the fee limit above the ceiling, the call is invalid
+// as is rather than the caller lacking a permission.
+func checkFeeLimit(limit, energy, ceiling int64) error {
+	if limit > ceiling {
+		return fmt.Errorf("fee limit: %w: %d SUN of %d energy exceeds %d SUN",
+			derrors.InvalidParam, limit, energy, ceiling)
+	}
+	return nil
+}
 
-	pub := internal.PrivateKeyToPublicKey(privateKey)
-	address := internal.EncodeCheck(internal.PublicKeyToTronAddress(pub))
+// FeeLimit returns the fee limit in SUN covering the energy with the safety
+// multiplier at the energy price in SUN.
+func FeeLimit(energy, price int64, multiplier float64) int64 {
+	margined := decimal.NewFromInt(energy).Mul(decimal.NewFromFloat(multiplier)).Ceil()
+	return margined.Mul(decimal.NewFromInt(price)).IntPart()
+}
 
-	transferContract := new(core.TriggerSmartContract)
-	transferContract.OwnerAddress = internal.DecodeCheck(address)
-	transferContract.ContractAddress = internal.DecodeCheck(contract)
-	transferContract.Data = data
-	transferContract.CallValue = callValue
+// EnergyPrice returns the price in SUN of the energy burnt by the transactions.
+func (e *Endpoint) EnergyPrice(ctx context.Context) (int64, error) {
+	return e.ChainParameter(ctx, "getEnergyFee")
+}
 
-	transferTransactionEx, err := e.wallet.TriggerConstantContract(ctx, transferContract)
+// UnfreezeDelayDays returns the days the staked TRX is locked after unstaking.
+func (e *Endpoint) UnfreezeDelayDays(ctx context.Context) (int64, error) {
+	return e.ChainParameter(ctx, "getUnfreezeDelayDays")
+}
+
+// ChainParameter returns the value of the chain parameter of the key.
+func (e *Endpoint) ChainParameter(ctx context.Context, key string) (int64, error) {
+	params, err := e.wallet.GetChainParameters(ctx, &api.EmptyMessage{})
 	if err != nil {
-		return nil, "", err
+		return 0, err
 	}
-	transferTransaction := transferTransactionEx.Transaction
-	if transferTransaction == nil ||
-		len(transferTransaction.GetRawData().GetContract()) == 0 {
-		return nil, "", fmt.Errorf("transfer error: invalid transaction")
+	for _, p := range params.GetChainParameter() {
+		if p.GetKey() == key {
+			return p.GetValue(), nil
+		}
 	}
-	var txId []byte
-	transferTransaction.RawData.FeeLimit = 200000000
-	txId, err = signTransaction(transferTransaction, privateKey)
+	return 0, fmt.Errorf("chain parameter %s: %w", key, derrors.NotFound)
+}
+
+// Balance returns the TRX balance in SUN of the account.
+func (e *Endpoint) Balance(ctx context.Context, address string) (int64, error) {
+	account, err := e.wallet.GetAccount(ctx, &core.Account{Address: internal.DecodeCheck(address)})
 	if err != nil {
-		return nil, "", err
+		return 0, err
 	}
-	return transferTransactionEx, hex.EncodeToString(txId), nil
+	return account.GetBalance(), nil
 }
 
-func (e *Endpoint) CalStackEnergy(ctx context.Context, owner string, energy int64, toSUN bool) (int64, error) {
-	return e.StackEnergy(ctx, owner, energy, toSUN)
+func (e *Endpoint) CalStackEnergy(ctx context.Context, owner string, amount int64, rt core.ResourceCode, toSUN bool) (int64, error) {
+	return e.StackEnergy(ctx, owner, amount, rt, toSUN)
 }
 
-// StackEnergy is a method that calculates the obtained energy based on the given energy value and the account's resource information.
-// It retrieves the account's resource and performs calculations based on the energy weight and energy limit.
-// The function returns the obtained energy value if successful, otherwise an error is returned.
+// StackEnergy is a method that calculates the TRX to stake for the given amount of the resource based on the account's resource information.
+// It retrieves the account's resource and performs calculations based on the weight and limit of the resource, i.e. the energy or the bandwidth.
+// The function returns the staked TRX, or SUN if toSUN is set, if successful, otherwise an error is returned.
 func (e *Endpoint) StackEnergy(ctx context.Context,
 	owner string,
-	energy int64,
+	amount int64,
+	rt core.ResourceCode,
 	toSUN bool,
 ) (int64, error) {
+	// The network totals are the same for every account, fallback to
+	// the neutral caller if the owner is not specified.
+	if internal.IsEmpty(owner) {
+		owner = ZeroAddress
+	}
 	// Retrieve the account's resource information
 	resource, err := e.GetAccountResource(ctx, owner)
-	if err != nil || resource == nil {
-		return -1, derrors.Forbidden
-	} else if energyWeight := decimal.NewFromInt(resource.GetTotalEnergyWeight()); energyWeight.IsZero() {
-		// Check for invalid energy weight
-		return -1, fmt.Errorf(`stackEnergy: invalid energy weight(%v)`, energyWeight)
-	} else if energyLimit := decimal.NewFromInt(resource.GetTotalEnergyLimit()); energyLimit.IsZero() {
-		// Check for invalid energy limit
-		return -1, fmt.Errorf(`stackEnergy: invalid energy limit(%v)`, energyLimit)
-	} else {
-		// Calculate the obtained energy based on the energy value, energy limit, and energy weight
-		proportion, _ := energyLimit.Div(energyWeight).Float64()
-		front := math.Ceil(float64(energy) / proportion)
-		if toSUN {
-			return int64(math.Ceil(front * 1000000)), nil
-		} else {
-			return int64(math.Ceil(front)), nil
-		}
+	if err != nil {
+		return -1, fmt.Errorf("%w: account resource: %v", derrors.Unavailable, err)
 	}
+	trx, err := StakeOf(resource, amount, rt)
+	if err != nil {
+		return -1, err
+	} else if toSUN {
+		return trx * SUNPerTRX, nil
+	}
+	return trx, nil
 }
 
-// BroadcastTransaction is a method that broadcasts a transaction to the wallet.
-// It calls the BroadcastTransaction method of the wallet to perform the broadcasting process.
-func (e *Endpoint) BroadcastTransaction(ctx context.Context, transaction *core.Transaction) (bool, error) {
-	if reply, err := e.wallet.BroadcastTransaction(ctx, transaction); err != nil {
-		return false, err
-	} else if !reply.Result {
-		return false, fmt.Errorf("%s", reply.String())
+// StakeOf returns the TRX to stake for the amount of the resource, given the
+// network totals of the account resource.
+func StakeOf(resource *api.AccountResourceMessage, amount int64, rt core.ResourceCode) (int64, error) {
+	var weight, limit int64
+	switch rt {
+	case core.ResourceCode_ENERGY:
+		weight, limit = resource.GetTotalEnergyWeight(), resource.GetTotalEnergyLimit()
+	case core.ResourceCode_BANDWIDTH:
+		weight, limit = resource.GetTotalNetWeight(), resource.GetTotalNetLimit()
+	default:
+		return -1, fmt.Errorf("stackEnergy: %w: resource type(%v)", derrors.InvalidParam, rt)
+	}
+	if weight <= 0 {
+		// Check for invalid weight
+		return -1, fmt.Errorf(`stackEnergy: invalid %v weight(%v)`, rt, weight)
+	} else if limit <= 0 {
+		// Check for invalid limit
+		return -1, fmt.Errorf(`stackEnergy: invalid %v limit(%v)`, rt, limit)
 	}
-	return true, nil
+	return StakeForEnergy(amount, weight, limit), nil
+}
+
+// StakeForEnergy returns the least TRX to stake for the energy, given the
+// total weight in TRX & the total limit of the resource in the network. The
+// resource of a stake is stake * limit / weight rounded down, so the stake
+// is amount * weight / limit rounded up. The bandwidth is calculated alike.
+func StakeForEnergy(energy, weight, limit int64) int64 {
+	n := new(big.Int).Mul(big.NewInt(energy), big.NewInt(weight))
+	return CeilDiv(n, big.NewInt(limit)).Int64()
+}
+
+// CeilDiv returns x / y rounded up for the non-negative x & the positive y.
+func CeilDiv(x, y *big.Int) *big.Int {
+	q, m := new(big.Int).QuoRem(x, y, new(big.Int))
+	if m.Sign() > 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
 }
 
 func (e *Endpoint) Close() error {
-	return e.grpc.Close()
+	if e.solidityConn != nil {
+		e.solidityConn.Close()
+	}
+	return e.pool.Close()
 }
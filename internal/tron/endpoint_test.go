@@ -0,0 +1,143 @@
+package tron
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"justlend/internal/derrors"
+	"justlend/internal/protos/api"
+	"justlend/internal/protos/core"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+// energyOf is the energy of staking the TRX, as the chain calculates it.
+func energyOf(trx, weight, limit int64) *big.Int {
+	n := new(big.Int).Mul(big.NewInt(trx), big.NewInt(limit))
+	return n.Quo(n, big.NewInt(weight))
+}
+
+func TestStakeForEnergy(t *testing.T) {
+	// The stake is the least one whose energy covers the requested energy.
+	f := func(e uint32, w, l uint64) bool {
+		energy := int64(e%1e9) + 1
+		weight := int64(w%1e12) + 1
+		limit := int64(l%1e12) + 1
+		trx := StakeForEnergy(energy, weight, limit)
+		return energyOf(trx, weight, limit).Cmp(big.NewInt(energy)) >= 0 &&
+			energyOf(trx-1, weight, limit).Cmp(big.NewInt(energy)) < 0
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 10000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStakeForEnergyExamples(t *testing.T) {
+	tests := []struct {
+		energy, weight, limit, want int64
+	}{
+		{energy: 1, weight: 1, limit: 1, want: 1},
+		{energy: 10, weight: 3, limit: 10, want: 3},
+		{energy: 11, weight: 3, limit: 10, want: 4},
+		// The product of the mainnet totals overflows int64.
+		{energy: 65000, weight: 18_000_000_000, limit: 180_000_000_000, want: 6500},
+		{energy: 1_000_000_000, weight: 19_000_000_000, limit: 180_000_000_000, want: 105_555_556},
+	}
+	for _, tt := range tests {
+		if got := StakeForEnergy(tt.energy, tt.weight, tt.limit); got != tt.want {
+			t.Errorf("StakeForEnergy(%d, %d, %d) = %d, want %d", tt.energy, tt.weight, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestToSUN(t *testing.T) {
+	// The SUN amounts survive the round trip through TRX exactly.
+	f := func(sun int64) bool {
+		if sun < 0 {
+			sun = -sun
+		}
+		return ToSUN(decimal.New(sun, -6)) == sun
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	// 0.29 * 1e6 is 289999.99999999994 in float64.
+	if got := ToSUN(decimal.RequireFromString("0.29")); got != 290000 {
+		t.Errorf("ToSUN(0.29) = %d, want 290000", got)
+	}
+	if got := ToSUN(decimal.RequireFromString("0.0000001")); got != 1 {
+		t.Errorf("ToSUN(0.0000001) = %d, want 1", got)
+	}
+}
+
+func TestStakeOf(t *testing.T) {
+	// The mainnet totals, the energy & the bandwidth differ by far.
+	resource := &api.AccountResourceMessage{
+		TotalEnergyWeight: 19_000_000_000,
+		TotalEnergyLimit:  180_000_000_000,
+		TotalNetWeight:    26_000_000_000,
+		TotalNetLimit:     43_200_000_000,
+	}
+	tests := []struct {
+		name     string
+		resource *api.AccountResourceMessage
+		amount   int64
+		rt       core.ResourceCode
+		want     int64
+		wantErr  bool
+	}{
+		{name: "energy", resource: resource, amount: 65_000, rt: core.ResourceCode_ENERGY, want: 6862},
+		{name: "bandwidth", resource: resource, amount: 345, rt: core.ResourceCode_BANDWIDTH, want: 208},
+		{name: "energy exact", resource: resource, amount: 180, rt: core.ResourceCode_ENERGY, want: 19},
+		{name: "bandwidth exact", resource: resource, amount: 432, rt: core.ResourceCode_BANDWIDTH, want: 260},
+		{name: "energy of bandwidth totals", resource: &api.AccountResourceMessage{
+			TotalNetWeight: 1, TotalNetLimit: 1,
+		}, amount: 1, rt: core.ResourceCode_ENERGY, wantErr: true},
+		{name: "bandwidth of energy totals", resource: &api.AccountResourceMessage{
+			TotalEnergyWeight: 1, TotalEnergyLimit: 1,
+		}, amount: 1, rt: core.ResourceCode_BANDWIDTH, wantErr: true},
+		{name: "zero limit", resource: &api.AccountResourceMessage{
+			TotalNetWeight: 1,
+		}, amount: 1, rt: core.ResourceCode_BANDWIDTH, wantErr: true},
+		{name: "tron power", resource: resource, amount: 1, rt: core.ResourceCode_TRON_POWER, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StakeOf(tt.resource, tt.amount, tt.rt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StakeOf() error = %v, wantErr %v", err, tt.wantErr)
+			} else if !tt.wantErr && got != tt.want {
+				t.Errorf("StakeOf() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeeLimit(t *testing.T) {
+	tests := []struct {
+		energy, price int64
+		multiplier    float64
+		want          int64
+	}{
+		{energy: 65_000, price: 420, multiplier: 1, want: 27_300_000},
+		{energy: 65_000, price: 420, multiplier: 1.2, want: 32_760_000},
+		// The margined energy is rounded up to a whole energy.
+		{energy: 3, price: 420, multiplier: 1.1, want: 4 * 420},
+		{energy: 130_000, price: 210, multiplier: 1.5, want: 40_950_000},
+	}
+	for _, tt := range tests {
+		if got := FeeLimit(tt.energy, tt.price, tt.multiplier); got != tt.want {
+			t.Errorf("FeeLimit(%d, %d, %v) = %d, want %d", tt.energy, tt.price, tt.multiplier, got, tt.want)
+		}
+	}
+}
+
+func TestCheckFeeLimit(t *testing.T) {
+	if err := checkFeeLimit(100_000_000, 65_000, 100_000_000); err != nil {
+		t.Errorf("checkFeeLimit() at the ceiling = %v", err)
+	}
+	// A fee limit above the ceiling is an invalid call, not a forbidden one.
+	if err := checkFeeLimit(100_000_001, 65_000, 100_000_000); !errors.Is(err, derrors.InvalidParam) {
+		t.Errorf("checkFeeLimit() above the ceiling = %v, want %v", err, derrors.InvalidParam)
+	}
+}
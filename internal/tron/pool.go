@@ -0,0 +1,254 @@
+package tron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"justlend/internal/log"
+	"justlend/internal/protos/api"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NodeStatus describes the health of a full node of the pool.
+type NodeStatus struct {
+	// Addr is the gRPC address of the node.
+	Addr string `json:"addr"`
+	// Healthy reports whether the node is used to serve the calls.
+	Healthy bool `json:"healthy"`
+	// BlockHeight is the latest block number reported by the node.
+	BlockHeight int64 `json:"blockHeight"`
+	// Lag is the number of blocks the node falls behind the best node.
+	Lag int64 `json:"lag"`
+	// Latency is the round trip time of the last probe in milliseconds.
+	Latency int64 `json:"latency"`
+	// Version is the code version of the node.
+	Version string `json:"version,omitempty"`
+	// Failures counts the consecutive failed calls & probes.
+	Failures int `json:"failures"`
+	// Error is the last error occurred calling the node.
+	Error string `json:"error,omitempty"`
+	// CheckedAt is the time of the last probe.
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// node is a full node of the pool.
+type node struct {
+	addr   string
+	conn   *grpc.ClientConn
+	wallet api.WalletClient
+
+	mu     sync.RWMutex
+	status NodeStatus
+}
+
+func (n *node) healthy() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.status.Healthy
+}
+
+// fail ejects the node after a failed call until the next successful probe.
+func (n *node) fail(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Healthy = false
+	n.status.Failures++
+	n.status.Error = err.Error()
+}
+
+// Pool is a pool of Tron full nodes that implements grpc.ClientConnInterface,
+// every call is served by the first healthy node of the pool in the order of
+// the configuration and fails over to the next node if the node is unavailable.
+// The health of the nodes is probed periodically by Run.
+type Pool struct {
+	nodes []*node
+
+	// Interval between two probes, a node lagging more than MaxLag blocks
+	// behind the best node is ejected from the pool.
+	Interval time.Duration
+	MaxLag   int64
+	// Timeout of each probe.
+	Timeout time.Duration
+}
+
+// NewPool creates a pool of the given nodes, each node is considered healthy
+// until it's probed. dial is used to create the connection to each node.
+func NewPool(dial func(NodeConfig) (*grpc.ClientConn, error), nodes ...NodeConfig) (*Pool, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("tron: no node configured")
+	}
+	p := &Pool{Interval: 10 * time.Second, MaxLag: 5, Timeout: 5 * time.Second}
+	for _, c := range nodes {
+		conn, err := dial(c)
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("dial %s: %w", c.Addr, err)
+		}
+		p.nodes = append(p.nodes, &node{
+			addr:   c.Addr,
+			conn:   conn,
+			wallet: api.NewWalletClient(conn),
+			status: NodeStatus{Addr: c.Addr, Healthy: true},
+		})
+	}
+	return p, nil
+}
+
+// candidates returns the healthy nodes followed by the unhealthy ones, so that
+// the calls are still attempted if all the nodes are ejected.
+func (p *Pool) candidates() []*node {
+	ns := make([]*node, 0, len(p.nodes))
+	for _, n := range p.nodes {
+		if n.healthy() {
+			ns = append(ns, n)
+		}
+	}
+	for _, n := range p.nodes {
+		if !n.healthy() {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+// retryable reports whether the call failed due to the node rather than the
+// request, so that it's safe to fail over to the next node.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Invoke implements grpc.ClientConnInterface.
+func (p *Pool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) (err error) {
+	for _, n := range p.candidates() {
+		if err = n.conn.Invoke(ctx, method, args, reply, opts...); err == nil || !retryable(ctx, err) {
+			return err
+		}
+		log.WarnW("tron node failed, failing over", "node", n.addr, "method", method, "error", err)
+		n.fail(err)
+	}
+	return err
+}
+
+// NewStream implements grpc.ClientConnInterface, streams are not failed over.
+func (p *Pool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.candidates()[0].conn.NewStream(ctx, desc, method, opts...)
+}
+
+// Run probes the nodes every Interval until the context is canceled.
+func (p *Pool) Run(ctx context.Context) error {
+	t := time.NewTicker(p.Interval)
+	defer t.Stop()
+	for {
+		p.Probe(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Probe checks the health of all the nodes concurrently, a node is healthy if
+// it answers the probe & doesn't lag more than MaxLag blocks behind the best node.
+func (p *Pool) Probe(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			p.probe(ctx, n)
+		}(n)
+	}
+	wg.Wait()
+
+	var best int64
+	for _, s := range p.Nodes() {
+		if s.Error == "" && s.BlockHeight > best {
+			best = s.BlockHeight
+		}
+	}
+	for _, n := range p.nodes {
+		n.mu.Lock()
+		n.status.Lag = best - n.status.BlockHeight
+		wasHealthy := n.status.Healthy
+		n.status.Healthy = n.status.Error == "" && n.status.Lag <= p.MaxLag
+		if wasHealthy != n.status.Healthy {
+			log.InfoW("tron node health changed", "node", n.addr, "healthy", n.status.Healthy,
+				"height", n.status.BlockHeight, "lag", n.status.Lag, "error", n.status.Error)
+		}
+		n.mu.Unlock()
+	}
+}
+
+func (p *Pool) probe(ctx context.Context, n *node) {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	block, err := n.wallet.GetNowBlock2(ctx, new(api.EmptyMessage))
+	latency := time.Since(start)
+	var version string
+	if err == nil {
+		// The node info is informative only, ignore the failure.
+		if info, e := n.wallet.GetNodeInfo(ctx, new(api.EmptyMessage)); e == nil {
+			version = info.GetConfigNodeInfo().GetCodeVersion()
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.CheckedAt = time.Now()
+	n.status.Latency = latency.Milliseconds()
+	if err != nil {
+		n.status.Failures++
+		n.status.Error = err.Error()
+		return
+	}
+	n.status.Failures = 0
+	n.status.Error = ""
+	n.status.BlockHeight = block.GetBlockHeader().GetRawData().GetNumber()
+	if version != "" {
+		n.status.Version = version
+	}
+}
+
+// Nodes returns the status of all the nodes of the pool, the healthy nodes
+// come first.
+func (p *Pool) Nodes() []NodeStatus {
+	ss := make([]NodeStatus, 0, len(p.nodes))
+	for _, n := range p.nodes {
+		n.mu.RLock()
+		ss = append(ss, n.status)
+		n.mu.RUnlock()
+	}
+	sort.SliceStable(ss, func(i, j int) bool { return ss[i].Healthy && !ss[j].Healthy })
+	return ss
+}
+
+// Close closes the connections to all the nodes.
+func (p *Pool) Close() error {
+	var err error
+	for _, n := range p.nodes {
+		if e := n.conn.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+var (
+	_ grpc.ClientConnInterface = (*Pool)(nil)
+)
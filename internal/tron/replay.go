@@ -0,0 +1,198 @@
+package tron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protov2 "google.golang.org/protobuf/proto"
+	"justlend/internal/config"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var (
+	// defaultReplayMode switches the traffic of the nodes to be recorded into
+	// or replayed from TRON_REPLAY_FILE, the nodes are called as usual if
+	// it's empty.
+	defaultReplayMode = ReplayMode(config.GetEnv("TRON_REPLAY", ""))
+	defaultReplayFile = config.GetEnv("TRON_REPLAY_FILE", "tron.golden.json")
+)
+
+// ReplayMode is the mode of a Recorder.
+type ReplayMode string
+
+const (
+	// ReplayOff calls the nodes without recording.
+	ReplayOff ReplayMode = ""
+	// ReplayRecord calls the nodes & records every call into the golden file.
+	ReplayRecord ReplayMode = "record"
+	// ReplayServe serves the calls from the golden file, the nodes are never
+	// called.
+	ReplayServe ReplayMode = "replay"
+)
+
+// DefaultReplayMode returns the replay mode of the configuration, i.e.
+// TRON_REPLAY, the golden tests refresh their fixtures if it's record.
+func DefaultReplayMode() ReplayMode { return defaultReplayMode }
+
+// Interaction is a recorded unary call, the messages are in the protojson
+// form. A failed call has the status code & the message of its error instead
+// of the response.
+type Interaction struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Code     codes.Code      `json:"code,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Recorder is a gRPC client interceptor which records the unary calls of the
+// nodes into a golden file, or serves them back from it.
+//
+// The calls are replayed by their method & request. The identical calls are
+// served the recorded responses in the order of the recording, the last one
+// is repeated once they are exhausted.
+type Recorder struct {
+	mode ReplayMode
+	file string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	// served counts the replayed calls of each method & request.
+	served map[string]int
+}
+
+// NewRecorder creates a recorder of the golden file. A recording starts
+// afresh & overwrites the file on its first call, a replay loads the file.
+func NewRecorder(mode ReplayMode, file string) (*Recorder, error) {
+	r := &Recorder{mode: mode, file: file, served: make(map[string]int)}
+	switch mode {
+	case ReplayOff, ReplayRecord:
+	case ReplayServe:
+		blob, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("replay: %w", err)
+		}
+		if err = json.Unmarshal(blob, &r.interactions); err != nil {
+			return nil, fmt.Errorf("replay: parse %s: %w", file, err)
+		}
+	default:
+		return nil, fmt.Errorf("replay: unknown mode %q", mode)
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() ReplayMode { return r.mode }
+
+// DialOption returns the dial option installing the recorder, it's nil if
+// the recorder is off.
+func (r *Recorder) DialOption() grpc.DialOption {
+	if r == nil || r.mode == ReplayOff {
+		return nil
+	}
+	return grpc.WithChainUnaryInterceptor(r.Intercept)
+}
+
+// Intercept implements grpc.UnaryClientInterceptor.
+func (r *Recorder) Intercept(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+	in, ok := req.(protov2.Message)
+	out, ok2 := reply.(protov2.Message)
+	if !ok || !ok2 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	switch r.mode {
+	case ReplayRecord:
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if rerr := r.record(method, in, out, err); rerr != nil {
+			return rerr
+		}
+		return err
+	case ReplayServe:
+		return r.replay(method, in, out)
+	default:
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// record appends the call to the golden file.
+func (r *Recorder) record(method string, req, reply protov2.Message, err error) error {
+	x := &Interaction{Method: method}
+	var merr error
+	if x.Request, merr = protojson.Marshal(req); merr != nil {
+		return fmt.Errorf("record %s: %w", method, merr)
+	}
+	if err != nil {
+		s := status.Convert(err)
+		x.Code, x.Error = s.Code(), s.Message()
+	} else if x.Response, merr = protojson.Marshal(reply); merr != nil {
+		return fmt.Errorf("record %s: %w", method, merr)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, x)
+	// The file is rewritten on every call, so that a recording is complete
+	// however the process exits.
+	blob, merr := json.MarshalIndent(r.interactions, "", "  ")
+	if merr != nil {
+		return fmt.Errorf("record %s: %w", method, merr)
+	}
+	if merr = os.MkdirAll(filepath.Dir(r.file), 0o755); merr != nil {
+		return fmt.Errorf("record %s: %w", method, merr)
+	}
+	tmp := r.file + ".tmp"
+	if merr = os.WriteFile(tmp, append(blob, '\n'), 0o644); merr != nil {
+		return fmt.Errorf("record %s: %w", method, merr)
+	}
+	return os.Rename(tmp, r.file)
+}
+
+// errNotRecorded is the error of a replayed call which was never recorded.
+var errNotRecorded = errors.New("call not recorded")
+
+// replay serves the recorded response of the call into the reply.
+func (r *Recorder) replay(method string, req, reply protov2.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matches []*Interaction
+	for _, x := range r.interactions {
+		if x.Method != method {
+			continue
+		}
+		recorded := req.ProtoReflect().New().Interface()
+		if err := protojson.Unmarshal(x.Request, recorded); err != nil {
+			return fmt.Errorf("replay %s: %w", method, err)
+		}
+		if protov2.Equal(req, recorded) {
+			matches = append(matches, x)
+		}
+	}
+	if len(matches) == 0 {
+		body, _ := protojson.Marshal(req)
+		// The precondition failure is not failed over by the pool.
+		return status.Errorf(codes.FailedPrecondition, "replay %s %s: %v", method, body, errNotRecorded)
+	}
+	key := method + string(matches[0].Request)
+	i := min(r.served[key], len(matches)-1)
+	r.served[key]++
+
+	x := matches[i]
+	if x.Code != codes.OK {
+		return status.Error(x.Code, x.Error)
+	}
+	protov2.Reset(reply)
+	if err := protojson.Unmarshal(x.Response, reply); err != nil {
+		return fmt.Errorf("replay %s: %w", method, err)
+	}
+	return nil
+}
@@ -1,34 +1,90 @@
 package tron
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/gogo/protobuf/proto"
+	protov2 "google.golang.org/protobuf/proto"
+	"justlend/internal"
+	"justlend/internal/derrors"
 	"justlend/internal/protos/core"
-	"time"
+	"strings"
 )
 
-func parsePrivateKey(private string) *ecdsa.PrivateKey {
-	privateKey, err := crypto.HexToECDSA(private)
-	if err != nil {
-		return nil
+// Signer signs the raw data hash of a transaction on behalf of the account of
+// the given base58 address, returns the 65 bytes recoverable secp256k1
+// signature. An implementation is expected to return derrors.NotFound if it
+// doesn't hold the key of the address.
+type Signer interface {
+	Sign(ctx context.Context, address string, hash []byte) ([]byte, error)
+}
+
+// Resolver is optionally implemented by the signers whose accounts can be
+// referred by an identifier other than the address, i.e. a keystore ID.
+type Resolver interface {
+	Resolve(idOrAddress string) (string, error)
+}
+
+// Lister is optionally implemented by the signers which can list the addresses
+// of their accounts.
+type Lister interface {
+	Addresses() []string
+}
+
+// LocalSigner is an in-process Signer holding the raw private keys in memory.
+type LocalSigner struct {
+	keys map[string]*ecdsa.PrivateKey // Keys indexed by base58 address.
+}
+
+// NewLocalSigner creates a new LocalSigner from the given hex private keys.
+func NewLocalSigner(privateKeys ...string) (*LocalSigner, error) {
+	s := &LocalSigner{keys: make(map[string]*ecdsa.PrivateKey, len(privateKeys))}
+	for _, k := range privateKeys {
+		pk, err := crypto.HexToECDSA(strings.TrimPrefix(k, "0x"))
+		if err != nil {
+			return nil, derrors.InvalidParam
+		}
+		s.keys[PublicKeyToAddress(&pk.PublicKey)] = pk
 	}
-	return privateKey
+	return s, nil
 }
 
-func signTransaction(transaction *core.Transaction, privateKey string) ([]byte, error) {
-	transaction.GetRawData().Timestamp = time.Now().UnixNano() / 1000000
-	rawData, err := proto.Marshal(transaction.GetRawData())
+// Addresses returns the addresses of all keys held by the signer.
+func (s *LocalSigner) Addresses() []string {
+	addrs := make([]string, 0, len(s.keys))
+	for a := range s.keys {
+		addrs = append(addrs, a)
+	}
+	return addrs
+}
+
+// Sign implements Signer.
+func (s *LocalSigner) Sign(_ context.Context, address string, hash []byte) ([]byte, error) {
+	pk, ok := s.keys[address]
+	if !ok {
+		return nil, derrors.NotFound
+	}
+	return crypto.Sign(hash, pk)
+}
+
+// PublicKeyToAddress returns the base58 Tron address of the public key.
+func PublicKeyToAddress(pub *ecdsa.PublicKey) string {
+	return internal.EncodeCheck(internal.PublicKeyToTronAddress(crypto.FromECDSAPub(pub)))
+}
+
+// signTransaction signs every contract of the transaction with the key of
+// the owner address, returns the hash of the raw data, which is the txID.
+func signTransaction(ctx context.Context, transaction *core.Transaction, signer Signer, owner string) ([]byte, error) {
+	hash, err := rawDataHash(transaction)
 	if err != nil {
 		return nil, err
 	}
-	h256h := sha256.New()
-	h256h.Write(rawData)
-	hash := h256h.Sum(nil)
 	contractList := transaction.GetRawData().GetContract()
 	for range contractList {
-		s, e := crypto.Sign(hash, parsePrivateKey(privateKey))
+		s, e := signer.Sign(ctx, owner, hash)
 		if e != nil {
 			return nil, e
 		}
@@ -36,3 +92,90 @@ func signTransaction(transaction *core.Transaction, privateKey string) ([]byte,
 	}
 	return hash, nil
 }
+
+// marshalRawData returns the bytes of the raw data of the transaction, which
+// are both hashed into the txID & exported for signing offline, so that the
+// offline signer hashes the very bytes of the txID.
+func marshalRawData(transaction *core.Transaction) ([]byte, error) {
+	return protov2.MarshalOptions{Deterministic: true}.Marshal(transaction.GetRawData())
+}
+
+// rawDataHash returns the sha256 hash of the raw data of the transaction.
+func rawDataHash(transaction *core.Transaction) ([]byte, error) {
+	rawData, err := marshalRawData(transaction)
+	if err != nil {
+		return nil, err
+	}
+	h256h := sha256.New()
+	h256h.Write(rawData)
+	return h256h.Sum(nil), nil
+}
+
+// SignTransaction signs the transaction with the key of the owner address
+// and returns its hex encoded txID, the raw data is signed as built.
+func SignTransaction(ctx context.Context, transaction *core.Transaction, signer Signer, owner string) (string, error) {
+	txId, err := signTransaction(ctx, transaction, signer, owner)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(txId), nil
+}
+
+// EncodeRawData returns the hex encoded raw data of the transaction, which
+// is exported for signing offline.
+func EncodeRawData(transaction *core.Transaction) (string, error) {
+	b, err := marshalRawData(transaction)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// DecodeRawData decodes the hex encoded raw data into an unsigned transaction.
+func DecodeRawData(s string) (*core.Transaction, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: raw data: %v", derrors.InvalidParam, err)
+	}
+	raw := new(core.TransactionRaw)
+	if err = protov2.Unmarshal(b, raw); err != nil {
+		return nil, fmt.Errorf("%w: raw data: %v", derrors.InvalidParam, err)
+	}
+	return &core.Transaction{RawData: raw}, nil
+}
+
+// EncodeTransaction returns the hex encoded signed transaction.
+func EncodeTransaction(transaction *core.Transaction) (string, error) {
+	b, err := protov2.Marshal(transaction)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// DecodeTransaction decodes the hex encoded signed transaction.
+func DecodeTransaction(s string) (*core.Transaction, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: transaction: %v", derrors.InvalidParam, err)
+	}
+	tx := new(core.Transaction)
+	if err = protov2.Unmarshal(b, tx); err != nil {
+		return nil, fmt.Errorf("%w: transaction: %v", derrors.InvalidParam, err)
+	}
+	return tx, nil
+}
+
+// TransactionId returns the hex encoded txID of the transaction, which is
+// the hash of its raw data.
+func TransactionId(transaction *core.Transaction) (string, error) {
+	hash, err := rawDataHash(transaction)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash), nil
+}
+
+var (
+	_ Signer = (*LocalSigner)(nil)
+)
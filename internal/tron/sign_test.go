@@ -0,0 +1,103 @@
+package tron
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"justlend/internal"
+	"justlend/internal/protos/core"
+	"testing"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	const key = "8e812436a0e3323166e1f0e8ba79e19e217b2c4a53c970d4cca0cfb1078979df"
+	signer, err := NewLocalSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := signer.Addresses()[0]
+	call, err := anypb.New(&core.TriggerSmartContract{
+		OwnerAddress:    internal.DecodeCheck(owner),
+		ContractAddress: internal.DecodeCheck(ZeroAddress),
+		Data:            []byte{0xa9, 0x05, 0x9c, 0xbb},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	built := &core.Transaction{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_TriggerSmartContract,
+			Parameter: call,
+		}},
+		RefBlockBytes: []byte{0x01, 0x02},
+		RefBlockHash:  []byte{0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a},
+		Expiration:    1700000060000,
+		Timestamp:     1700000000000,
+		FeeLimit:      30 * SUNPerTRX,
+	}}
+	txId, err := TransactionId(built)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Export the raw data, sign it offline & import the signed transaction.
+	raw, err := EncodeRawData(built)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := hex.DecodeString(raw); hex.EncodeToString(sha256Sum(b)) != txId {
+		t.Fatalf("sha256(raw data) = %x, want the txID %s", sha256Sum(b), txId)
+	}
+	tx, err := DecodeRawData(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := EncodeRawData(tx); err != nil || again != raw {
+		t.Fatalf("raw data of the decoded transaction = %s, %v, want %s", again, err, raw)
+	}
+	signedId, err := SignTransaction(context.Background(), tx, signer, owner)
+	if err != nil {
+		t.Fatal(err)
+	} else if signedId != txId {
+		t.Fatalf("signed txID = %s, want %s", signedId, txId)
+	}
+	encoded, err := EncodeTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, err := DecodeTransaction(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := TransactionId(imported); err != nil || got != txId {
+		t.Fatalf("imported txID = %s, %v, want %s", got, err, txId)
+	}
+	if len(imported.GetSignature()) != 1 {
+		t.Fatalf("got %d signatures, want 1", len(imported.GetSignature()))
+	}
+	hash, _ := hex.DecodeString(txId)
+	pub, err := crypto.SigToPub(hash, imported.GetSignature()[0])
+	if err != nil {
+		t.Fatal(err)
+	} else if got := PublicKeyToAddress(pub); got != owner {
+		t.Errorf("signed by %s, want %s", got, owner)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, s := range []string{"zz", "ff"} {
+		if _, err := DecodeRawData(s); err == nil {
+			t.Errorf("DecodeRawData(%q) succeeded", s)
+		}
+		if _, err := DecodeTransaction(s); err == nil {
+			t.Errorf("DecodeTransaction(%q) succeeded", s)
+		}
+	}
+}
+
+func sha256Sum(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
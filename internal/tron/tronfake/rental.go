@@ -0,0 +1,199 @@
+package tronfake
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"justlend/internal"
+	"justlend/internal/abi"
+	"justlend/internal/justlend"
+	"justlend/internal/justlend/contract"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"math/big"
+	"time"
+)
+
+type rentalKey struct {
+	renter, receiver string
+	rt               core.ResourceCode
+}
+
+// rental is a rental of the resource of the staked amount in SUN, the rent
+// accrues since the last settlement.
+type rental struct {
+	amount, deposit *big.Int
+	since           time.Time
+}
+
+// result is the outcome of a contract call, a reverted call has a reason.
+// The state changes of the call are made by apply.
+type result struct {
+	energy int64
+	out    []byte
+	reason string
+	apply  func()
+}
+
+func (r *result) reverted() bool { return r.reason != "" }
+
+// revert returns the result of the call reverted for the reason, whose
+// output is the `Error(string)` revert data.
+func (s *Server) revert(reason string) *result {
+	out, _ := errorMethod.Pack(reason)
+	return &result{energy: s.params.CallEnergy, out: out, reason: reason}
+}
+
+var errorMethod = abi.MustParseMethod("Error(string)")
+
+func errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("tronfake: "+format, args...)
+}
+
+// call executes the call of the rental contract, the caller must hold the
+// lock. The changes of the state are only made by the apply of the result,
+// so a call is simulated as well.
+func (s *Server) call(in *core.TriggerSmartContract) (*result, error) {
+	if internal.EncodeCheck(in.GetContractAddress()) != justlend.JustLendContract {
+		return nil, errorf("contract %s not found", internal.EncodeCheck(in.GetContractAddress()))
+	}
+	data := in.GetData()
+	for name, m := range contract.RentalABI.Methods {
+		if len(data) < 4 || !bytes.Equal(m.ID, data[:4]) {
+			continue
+		}
+		if err := s.failed(name); err != nil {
+			return nil, err
+		}
+		args, err := m.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		if !m.IsConstant() {
+			return s.transact(name, internal.EncodeCheck(in.GetOwnerAddress()), in.GetCallValue(), args), nil
+		}
+		values, err := s.view(name, args)
+		if err != nil {
+			return nil, err
+		}
+		out, err := m.Outputs.Pack(values...)
+		if err != nil {
+			return nil, err
+		}
+		return &result{out: out, apply: func() {}}, nil
+	}
+	return s.revert("unknown method"), nil
+}
+
+// view returns the outputs of the read-only method.
+func (s *Server) view(name string, args []interface{}) ([]interface{}, error) {
+	switch name {
+	case "liquidateThreshold":
+		return []interface{}{big.NewInt(s.params.LiquidateThreshold)}, nil
+	case "minFee":
+		return []interface{}{big.NewInt(s.params.MinFee)}, nil
+	case "feeRatio":
+		return []interface{}{s.params.FeeRatio}, nil
+	case "_rentalRate":
+		return []interface{}{s.params.RentalRate}, nil
+	case "getRentInfo":
+		k := rentalKey{address(args[0]), address(args[1]), core.ResourceCode(args[2].(*big.Int).Int64())}
+		r, ok := s.rentals[k]
+		if !ok {
+			return []interface{}{new(big.Int), new(big.Int), new(big.Int)}, nil
+		}
+		return []interface{}{r.amount, r.deposit, s.accrued(r)}, nil
+	}
+	return nil, errorf("view %s not faked", name)
+}
+
+// transact executes the method changing the state on behalf of the owner.
+func (s *Server) transact(name, owner string, callValue int64, args []interface{}) *result {
+	k := rentalKey{owner, address(args[0]), core.ResourceCode(args[2].(*big.Int).Int64())}
+	amount := args[1].(*big.Int)
+	if amount.Sign() <= 0 {
+		return s.revert("invalid amount")
+	} else if !internal.Contains(k.rt, core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY) {
+		return s.revert("invalid resource type")
+	}
+
+	switch name {
+	case "rentResource":
+		// The call value pays the fee, the rest is the security deposit.
+		fee := new(big.Int).Mul(amount, s.params.FeeRatio)
+		fee.Quo(fee, big.NewInt(1e18))
+		if fee.Cmp(big.NewInt(s.params.MinFee)) < 0 {
+			fee = big.NewInt(s.params.MinFee)
+		}
+		deposit := new(big.Int).Sub(big.NewInt(callValue), fee)
+		if deposit.Cmp(big.NewInt(s.params.LiquidateThreshold)) < 0 {
+			return s.revert("insufficient security deposit")
+		}
+		return &result{energy: s.params.CallEnergy, apply: func() {
+			s.account(owner).balance -= callValue
+			r, ok := s.rentals[k]
+			if !ok {
+				r = &rental{amount: new(big.Int), deposit: new(big.Int), since: s.now()}
+				s.rentals[k] = r
+			}
+			s.settle(r)
+			r.amount.Add(r.amount, amount)
+			r.deposit.Add(r.deposit, deposit)
+			s.delegate(k, amount.Int64())
+		}}
+	case "returnResource":
+		r, ok := s.rentals[k]
+		if !ok || r.amount.Cmp(amount) < 0 {
+			return s.revert("insufficient rental amount")
+		}
+		return &result{energy: s.params.CallEnergy, apply: func() {
+			// The remaining deposit is refunded in the share of the
+			// returned amount.
+			s.settle(r)
+			refund := new(big.Int).Mul(r.deposit, amount)
+			refund.Quo(refund, r.amount)
+			r.amount.Sub(r.amount, amount)
+			r.deposit.Sub(r.deposit, refund)
+			if r.amount.Sign() == 0 {
+				delete(s.rentals, k)
+			}
+			s.account(owner).balance += refund.Int64()
+			s.delegate(k, -amount.Int64())
+		}}
+	}
+	return s.revert("unknown method")
+}
+
+// accrued returns the rent accrued by the rental since the last settlement.
+func (s *Server) accrued(r *rental) *big.Int {
+	elapsed := int64(s.now().Sub(r.since) / time.Second)
+	rent := new(big.Int).Mul(r.amount, s.params.RentalRate)
+	return rent.Mul(rent, big.NewInt(max(elapsed, 0))).Quo(rent, big.NewInt(1e18))
+}
+
+// settle deducts the accrued rent from the deposit of the rental.
+func (s *Server) settle(r *rental) {
+	accrued := s.accrued(r)
+	if accrued.Cmp(r.deposit) > 0 {
+		accrued.Set(r.deposit)
+	}
+	r.deposit.Sub(r.deposit, accrued)
+	r.since = s.now()
+}
+
+// delegate delegates the resource of the staked amount in SUN to the receiver
+// of the rental, or undelegates if the amount is negative.
+func (s *Server) delegate(k rentalKey, amount int64) {
+	a := s.account(k.receiver)
+	trx := amount / tron.SUNPerTRX
+	if k.rt == core.ResourceCode_ENERGY {
+		a.energyLimit += trx * s.params.TotalEnergyLimit / s.params.TotalEnergyWeight
+	} else {
+		a.netLimit += trx * s.params.TotalNetLimit / s.params.TotalNetWeight
+	}
+}
+
+// address returns the base58 address of the decoded ABI address.
+func address(v interface{}) string {
+	return internal.EncodeCheck(abi.FromEthAddress(v.(common.Address)))
+}
@@ -0,0 +1,423 @@
+// Package tronfake is an in-memory Tron full node for the tests, it serves the
+// wallet API over bufconn & simulates the JustLend DAO energy rental contract.
+//
+// Every broadcast transaction is packed into a new block right away, so its
+// receipt is final by the first poll. The accounts are funded by SetBalance,
+// the calls of any RPC or contract method may be made to fail by Fail as on
+// an unavailable node.
+package tronfake
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"justlend/internal"
+	"justlend/internal/protos/api"
+	"justlend/internal/protos/core"
+	"justlend/internal/tron"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+)
+
+// Params are the chain parameters of the node & the parameters of the rental
+// contract, the amounts are in SUN.
+type Params struct {
+	// EnergyFee is the price of the energy burnt by the transactions.
+	EnergyFee         int64
+	UnfreezeDelayDays int64
+	// The network totals of the staked TRX & the resource.
+	TotalEnergyWeight, TotalEnergyLimit int64
+	TotalNetWeight, TotalNetLimit       int64
+
+	LiquidateThreshold int64
+	MinFee             int64
+	// RentalRate & FeeRatio are scaled by 1e18, the rate is per second.
+	RentalRate, FeeRatio *big.Int
+	// CallEnergy is the energy consumed by every call of the rental contract.
+	CallEnergy int64
+}
+
+// DefaultParams are the parameters alike the mainnet.
+func DefaultParams() Params {
+	return Params{
+		EnergyFee:          420,
+		UnfreezeDelayDays:  14,
+		TotalEnergyWeight:  10000000000,
+		TotalEnergyLimit:   90000000000,
+		TotalNetWeight:     30000000000,
+		TotalNetLimit:      43200000000,
+		LiquidateThreshold: 1000000,
+		MinFee:             1000000,
+		RentalRate:         big.NewInt(15000000000),
+		FeeRatio:           big.NewInt(5000000000000000),
+		CallEnergy:         40000,
+	}
+}
+
+// account is the state of an account, the resource limits include the
+// resource delegated by the rentals.
+type account struct {
+	balance                 int64
+	energyLimit, energyUsed int64
+	netLimit, netUsed       int64
+}
+
+// Server is the fake full node.
+type Server struct {
+	api.UnimplementedWalletServer
+
+	lis *bufconn.Listener
+	srv *grpc.Server
+
+	mu       sync.Mutex
+	params   Params
+	offset   time.Duration // Offset of the clock of the node.
+	block    int64
+	accounts map[string]*account // Accounts by base58 address.
+	rentals  map[rentalKey]*rental
+	txs      map[string]*core.TransactionInfo // Packed transactions by txID.
+	failing  map[string]bool
+}
+
+// New starts a node of the parameters.
+func New(p Params) *Server {
+	s := &Server{
+		lis:      bufconn.Listen(1 << 20),
+		srv:      grpc.NewServer(),
+		params:   p,
+		block:    1,
+		accounts: make(map[string]*account),
+		rentals:  make(map[rentalKey]*rental),
+		txs:      make(map[string]*core.TransactionInfo),
+		failing:  make(map[string]bool),
+	}
+	api.RegisterWalletServer(s.srv, s)
+	go s.srv.Serve(s.lis)
+	return s
+}
+
+// Close stops the node.
+func (s *Server) Close() { s.srv.Stop() }
+
+// Dial connects the node whatever the config, it's the dial of a tron.Pool.
+func (s *Server) Dial(tron.NodeConfig) (*grpc.ClientConn, error) {
+	return grpc.NewClient("passthrough:///tronfake",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// Endpoint creates an endpoint to the node.
+func (s *Server) Endpoint() (*tron.Endpoint, error) {
+	pool, err := tron.NewPool(s.Dial, tron.NodeConfig{Addr: "tronfake"})
+	if err != nil {
+		return nil, err
+	}
+	return tron.NewEndpointFromPool(pool), nil
+}
+
+// Fail makes the RPCs or the contract methods of the names fail, i.e.
+// `GetAccountResource` or `feeRatio`.
+func (s *Server) Fail(names ...string) { s.setFailing(true, names) }
+
+// Recover makes the failed RPCs or contract methods succeed again.
+func (s *Server) Recover(names ...string) { s.setFailing(false, names) }
+
+func (s *Server) setFailing(failed bool, names []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range names {
+		s.failing[n] = failed
+	}
+}
+
+// failed returns the error of the failing RPC or contract method, the caller
+// must hold the lock.
+func (s *Server) failed(name string) error {
+	if s.failing[name] {
+		return status.Errorf(codes.Unavailable, "tronfake: %s unavailable", name)
+	}
+	return nil
+}
+
+// Advance moves the clock of the node forward, the rentals accrue the rent
+// & the transactions expire along with it.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset += d
+}
+
+func (s *Server) now() time.Time { return time.Now().Add(s.offset) }
+
+// SetBalance sets the TRX balance of the account.
+func (s *Server) SetBalance(address string, balance int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account(address).balance = balance
+}
+
+// Balance returns the TRX balance of the account.
+func (s *Server) Balance(address string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account(address).balance
+}
+
+// Energy returns the energy limit & the energy used of the account.
+func (s *Server) Energy(address string) (limit, used int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := s.account(address)
+	return a.energyLimit, a.energyUsed
+}
+
+// account returns the account of the address, which is created if unknown.
+func (s *Server) account(address string) *account {
+	a, ok := s.accounts[address]
+	if !ok {
+		a = new(account)
+		s.accounts[address] = a
+	}
+	return a
+}
+
+func (s *Server) GetAccount(_ context.Context, in *core.Account) (*core.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("GetAccount"); err != nil {
+		return nil, err
+	}
+	return &core.Account{
+		Address: in.GetAddress(),
+		Balance: s.account(internal.EncodeCheck(in.GetAddress())).balance,
+	}, nil
+}
+
+func (s *Server) GetAccountResource(_ context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("GetAccountResource"); err != nil {
+		return nil, err
+	}
+	a := s.account(internal.EncodeCheck(in.GetAddress()))
+	return &api.AccountResourceMessage{
+		EnergyLimit:       a.energyLimit,
+		EnergyUsed:        a.energyUsed,
+		NetLimit:          a.netLimit,
+		NetUsed:           a.netUsed,
+		TotalEnergyWeight: s.params.TotalEnergyWeight,
+		TotalEnergyLimit:  s.params.TotalEnergyLimit,
+		TotalNetWeight:    s.params.TotalNetWeight,
+		TotalNetLimit:     s.params.TotalNetLimit,
+	}, nil
+}
+
+func (s *Server) GetChainParameters(context.Context, *api.EmptyMessage) (*core.ChainParameters, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("GetChainParameters"); err != nil {
+		return nil, err
+	}
+	return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+		{Key: "getEnergyFee", Value: s.params.EnergyFee},
+		{Key: "getUnfreezeDelayDays", Value: s.params.UnfreezeDelayDays},
+	}}, nil
+}
+
+func (s *Server) GetNowBlock2(context.Context, *api.EmptyMessage) (*api.BlockExtention, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("GetNowBlock2"); err != nil {
+		return nil, err
+	}
+	return &api.BlockExtention{
+		Blockid: blockId(s.block),
+		BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{
+			Number:    s.block,
+			Timestamp: s.now().UnixMilli(),
+		}},
+	}, nil
+}
+
+// blockId returns the ID of the block, whose first 8 bytes are the number.
+func blockId(number int64) []byte {
+	id := sha256.Sum256(binary.BigEndian.AppendUint64(nil, uint64(number)))
+	binary.BigEndian.PutUint64(id[:8], uint64(number))
+	return id[:]
+}
+
+func (s *Server) TriggerConstantContract(_ context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("TriggerConstantContract"); err != nil {
+		return nil, err
+	}
+	r, err := s.call(in)
+	if err != nil {
+		return nil, err
+	}
+	reply := &api.TransactionExtention{
+		Result:      &api.Return{Result: true},
+		EnergyUsed:  r.energy,
+		Transaction: &core.Transaction{Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_SUCCESS}}},
+	}
+	if r.out != nil {
+		reply.ConstantResult = [][]byte{r.out}
+	}
+	if r.reverted() {
+		reply.Transaction.Ret[0].ContractRet = core.Transaction_Result_REVERT
+		reply.Result.Message = []byte("REVERT opcode executed")
+	}
+	return reply, nil
+}
+
+func (s *Server) TriggerContract(_ context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("TriggerContract"); err != nil {
+		return nil, err
+	}
+	param, err := anypb.New(in)
+	if err != nil {
+		return nil, err
+	}
+	now, id := s.now(), blockId(s.block)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_TriggerSmartContract,
+			Parameter: param,
+		}},
+		RefBlockBytes: id[6:8],
+		RefBlockHash:  id[8:16],
+		Expiration:    now.Add(time.Minute).UnixMilli(),
+		Timestamp:     now.UnixMilli(),
+	}}
+	txId, err := tron.TransactionId(tx)
+	if err != nil {
+		return nil, err
+	}
+	txid, _ := hex.DecodeString(txId)
+	return &api.TransactionExtention{
+		Transaction: tx,
+		Txid:        txid,
+		Result:      &api.Return{Result: true},
+	}, nil
+}
+
+func (s *Server) BroadcastTransaction(_ context.Context, tx *core.Transaction) (*api.Return, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("BroadcastTransaction"); err != nil {
+		return nil, err
+	}
+	txId, err := tron.TransactionId(tx)
+	if err != nil {
+		return nil, err
+	}
+	call, code, err := s.validate(txId, tx)
+	if err != nil {
+		return &api.Return{Code: code, Message: []byte(err.Error())}, nil
+	}
+
+	// Pack the transaction into a new block, the energy of the owner is
+	// consumed first & the rest is burnt.
+	r, err := s.call(call)
+	if err != nil {
+		return &api.Return{Code: api.Return_CONTRACT_VALIDATE_ERROR, Message: []byte(err.Error())}, nil
+	}
+	owner := s.account(internal.EncodeCheck(call.GetOwnerAddress()))
+	consumed := min(r.energy, max(owner.energyLimit-owner.energyUsed, 0))
+	owner.energyUsed += consumed
+	energyFee := (r.energy - consumed) * s.params.EnergyFee
+	if energyFee > tx.GetRawData().GetFeeLimit() {
+		r = &result{energy: r.energy, reason: "out of energy"}
+		energyFee = tx.GetRawData().GetFeeLimit()
+	} else if !r.reverted() {
+		r.apply()
+	}
+	owner.balance -= energyFee
+
+	s.block++
+	id, _ := hex.DecodeString(txId)
+	info := &core.TransactionInfo{
+		Id:             id,
+		Fee:            energyFee,
+		BlockNumber:    s.block,
+		BlockTimeStamp: s.now().UnixMilli(),
+		Receipt: &core.ResourceReceipt{
+			EnergyUsage:      consumed,
+			EnergyFee:        energyFee,
+			EnergyUsageTotal: r.energy,
+			Result:           core.Transaction_Result_SUCCESS,
+		},
+	}
+	if r.out != nil {
+		info.ContractResult = [][]byte{r.out}
+	}
+	if r.reverted() {
+		info.Result = core.TransactionInfo_FAILED
+		info.Receipt.Result = core.Transaction_Result_REVERT
+		info.ResMessage = []byte(r.reason)
+	}
+	s.txs[txId] = info
+	return &api.Return{Result: true, Code: api.Return_SUCCESS}, nil
+}
+
+// validate validates the broadcast transaction like a full node does before
+// it's accepted, & returns the contract call of the transaction.
+func (s *Server) validate(txId string, tx *core.Transaction) (*core.TriggerSmartContract, api.ReturnResponseCode, error) {
+	if _, ok := s.txs[txId]; ok {
+		return nil, api.Return_DUP_TRANSACTION_ERROR, errorf("dup transaction")
+	} else if time.UnixMilli(tx.GetRawData().GetExpiration()).Before(s.now()) {
+		return nil, api.Return_TRANSACTION_EXPIRATION_ERROR, errorf("transaction expired")
+	}
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) != 1 || contracts[0].GetType() != core.Transaction_Contract_TriggerSmartContract {
+		return nil, api.Return_CONTRACT_VALIDATE_ERROR, errorf("not a contract call")
+	}
+	call := new(core.TriggerSmartContract)
+	if err := contracts[0].GetParameter().UnmarshalTo(call); err != nil {
+		return nil, api.Return_CONTRACT_VALIDATE_ERROR, err
+	}
+	// The transaction must be signed by the owner of the call.
+	owner := internal.EncodeCheck(call.GetOwnerAddress())
+	hash, _ := hex.DecodeString(txId)
+	if len(tx.GetSignature()) != 1 {
+		return nil, api.Return_SIGERROR, errorf("signature missing")
+	} else if pub, err := crypto.SigToPub(hash, tx.GetSignature()[0]); err != nil {
+		return nil, api.Return_SIGERROR, err
+	} else if tron.PublicKeyToAddress(pub) != owner {
+		return nil, api.Return_SIGERROR, errorf("signed by %s, not the owner", tron.PublicKeyToAddress(pub))
+	}
+	if s.account(owner).balance < call.GetCallValue() {
+		return nil, api.Return_CONTRACT_VALIDATE_ERROR, errorf("balance is not sufficient")
+	}
+	return call, api.Return_SUCCESS, nil
+}
+
+func (s *Server) GetTransactionInfoById(_ context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.failed("GetTransactionInfoById"); err != nil {
+		return nil, err
+	}
+	info, ok := s.txs[hex.EncodeToString(in.GetValue())]
+	if !ok {
+		// The full nodes answer an empty info for the unknown transactions.
+		return new(core.TransactionInfo), nil
+	}
+	return protov2.Clone(info).(*core.TransactionInfo), nil
+}
@@ -0,0 +1,170 @@
+// Package wallet implements a managed keystore of the wallets used to sign
+// the rent & return transactions. The keys are stored on disk as encrypted
+// key files of the Ethereum keystore format (scrypt + AES-128-CTR), since a
+// Tron key is a secp256k1 key like an Ethereum key, and they are unlocked once
+// at the daemon start instead of passing the raw keys in every request.
+package wallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"justlend/internal/derrors"
+	"justlend/internal/tron"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Wallet represents an unlocked key of the keystore.
+type Wallet struct {
+	// ID is the identifier of the key file.
+	ID string `json:"id"`
+	// Address is the base58 Tron address of the key.
+	Address string `json:"address"`
+
+	key *ecdsa.PrivateKey
+}
+
+// Keystore holds all the unlocked wallets of a keystore directory.
+type Keystore struct {
+	dir string
+
+	mu      sync.RWMutex
+	wallets map[string]*Wallet // Wallets indexed by both ID & address.
+}
+
+// Open decrypts all the key files in the given directory with the given
+// passphrase and returns a keystore holding the unlocked wallets. A key file
+// that cannot be decrypted aborts the opening.
+func Open(dir, passphrase string) (*Keystore, error) {
+	ks := &Keystore{dir: dir, wallets: make(map[string]*Wallet)}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		// Skip directories, editor backups & hidden files.
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") || strings.HasSuffix(e.Name(), "~") {
+			continue
+		}
+		blob, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		key, err := keystore.DecryptKey(blob, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt %s: %w", e.Name(), err)
+		}
+		ks.add(newWallet(key))
+	}
+	return ks, nil
+}
+
+func newWallet(key *keystore.Key) *Wallet {
+	return &Wallet{
+		ID:      key.Id.String(),
+		Address: tron.PublicKeyToAddress(&key.PrivateKey.PublicKey),
+		key:     key.PrivateKey,
+	}
+}
+
+func (ks *Keystore) add(w *Wallet) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.wallets[w.ID] = w
+	ks.wallets[w.Address] = w
+}
+
+// Find returns the unlocked wallet whose ID or base58 address equals to the
+// given identifier, derrors.NotFound returned if no such wallet.
+func (ks *Keystore) Find(idOrAddress string) (*Wallet, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	if w, ok := ks.wallets[idOrAddress]; ok {
+		return w, nil
+	}
+	return nil, derrors.NotFound
+}
+
+// Resolve implements tron.Resolver.
+func (ks *Keystore) Resolve(idOrAddress string) (string, error) {
+	w, err := ks.Find(idOrAddress)
+	if err != nil {
+		return "", err
+	}
+	return w.Address, nil
+}
+
+// Sign implements tron.Signer.
+func (ks *Keystore) Sign(_ context.Context, address string, hash []byte) ([]byte, error) {
+	w, err := ks.Find(address)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, w.key)
+}
+
+// Addresses implements tron.Lister, the addresses are sorted.
+func (ks *Keystore) Addresses() []string {
+	ws := ks.Wallets()
+	addrs := make([]string, len(ws))
+	for i, w := range ws {
+		addrs[i] = w.Address
+	}
+	return addrs
+}
+
+// Wallets returns all the unlocked wallets sorted by address.
+func (ks *Keystore) Wallets() []*Wallet {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	ws := make([]*Wallet, 0, len(ks.wallets)/2)
+	for k, w := range ks.wallets {
+		if k == w.Address {
+			ws = append(ws, w)
+		}
+	}
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Address < ws[j].Address })
+	return ws
+}
+
+// Import encrypts the given hex private key with the passphrase and writes
+// the key file into the keystore directory, the directory is created if it
+// does not exist yet.
+func Import(dir, privateKey, passphrase string) (*Wallet, error) {
+	pk, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(pk.PublicKey),
+		PrivateKey: pk,
+	}
+	blob, err := keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, err
+	}
+	w := newWallet(key)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	// Use the naming convention of the Ethereum keystore files.
+	name := fmt.Sprintf("UTC--%s--%s.json", time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"), w.Address)
+	if err = os.WriteFile(filepath.Join(dir, name), blob, 0600); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+var (
+	_ tron.Signer   = (*Keystore)(nil)
+	_ tron.Resolver = (*Keystore)(nil)
+)